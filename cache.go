@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// parseCache stores the parse result of each file on disk, keyed by the file
+// path and the options that change the result. An entry is only reused while
+// the modification time and size of the file are unchanged.
+type parseCache struct {
+	dir     string
+	options string
+	logger  *log.Logger
+	hits    atomic.Int64
+}
+
+type cacheEntry struct {
+	Path    string `json:"path"`
+	ModTime int64  `json:"mod_time"`
+	Size    int64  `json:"size"`
+	Funcs   Func   `json:"funcs"`
+}
+
+func newParseCache(dir string, p Param, logger *log.Logger) (*parseCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %w", err)
+	}
+	options := fmt.Sprintf("v%d body=%t exported=%t undocumented=%t group=%t visibility=%t complexity=%d exclude=%v include=%v packages=%q",
+		schemaVersion, p.IncludeBody, p.ExportedOnly, p.UndocumentedOnly, p.GroupMethods, p.MarkVisibility, p.MinComplexity, p.ExcludeFuncs, p.IncludeFuncs, p.Packages)
+	return &parseCache{dir: dir, options: options, logger: logger}, nil
+}
+
+func (c *parseCache) entryPath(root, path string) string {
+	sum := sha256.Sum256([]byte(c.options + "\x00" + root + "\x00" + path))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *parseCache) load(p Param, info os.FileInfo) (Func, bool) {
+	b, err := os.ReadFile(c.entryPath(p.Root, p.FilePath))
+	if err != nil {
+		return Func{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return Func{}, false
+	}
+	if entry.Path != p.FilePath || entry.ModTime != info.ModTime().UnixNano() || entry.Size != info.Size() {
+		return Func{}, false
+	}
+	c.hits.Add(1)
+	return entry.Funcs, true
+}
+
+func (c *parseCache) store(p Param, info os.FileInfo, funcs Func) {
+	entry := cacheEntry{
+		Path:    p.FilePath,
+		ModTime: info.ModTime().UnixNano(),
+		Size:    info.Size(),
+		Funcs:   funcs,
+	}
+	b, err := json.Marshal(entry)
+	if err == nil {
+		err = os.WriteFile(c.entryPath(p.Root, p.FilePath), b, 0644)
+	}
+	if err != nil {
+		c.logger.Printf("failed to cache %s: %v", p.FilePath, err)
+	}
+}
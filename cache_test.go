@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCache(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go": "package p\n\nfunc A() {}\n",
+		"b.go": "package p\n\nfunc B() {}\n",
+		"c.go": "package p\n\nfunc C() {}\n",
+	})
+	cacheDir := t.TempDir()
+	p := newTestProcessor(t, root)
+
+	// parse runs a full parse through a fresh cache and reports how many
+	// files were loaded from it.
+	parse := func(param Param) (Func, int64) {
+		t.Helper()
+		goFiles, err := p.findGoFiles()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache, err := newParseCache(cacheDir, param, log.New(io.Discard, "", 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		funcs, _, errs := parseFunctions(context.Background(), goFiles, param, 1, nil, cache)
+		if len(errs) != 0 {
+			t.Fatal(errs)
+		}
+		funcs.Sort()
+		return funcs, cache.hits.Load()
+	}
+
+	first, hits := parse(Param{})
+	if hits != 0 {
+		t.Errorf("first run loaded %d files from an empty cache", hits)
+	}
+	second, hits := parse(Param{})
+	if hits != 3 {
+		t.Errorf("second run loaded %d files from cache, want 3", hits)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("cached result differs from the parsed one:\n%+v\n%+v", first, second)
+	}
+
+	b := filepath.Join(root, "b.go")
+	if err := os.WriteFile(b, []byte("package p\n\nfunc D() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(b, later, later); err != nil {
+		t.Fatal(err)
+	}
+	touched, hits := parse(Param{})
+	if hits != 2 {
+		t.Errorf("run after touching b.go loaded %d files from cache, want 2", hits)
+	}
+	if got, want := functionNames(touched.FunctionDescriptions), []string{"A", "D", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("functions = %q, want %q", got, want)
+	}
+
+	if _, hits := parse(Param{IncludeBody: true}); hits != 0 {
+		t.Errorf("run with other options loaded %d files from cache, want 0", hits)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type callGraphNode struct {
+	id       string
+	pkg      string
+	name     string
+	isMethod bool
+}
+
+// buildCallGraph renders the project's functions as a Graphviz digraph. Calls
+// are resolved by name only: a bare call must match a function in the same
+// package, a qualified call must match a function in the package named by
+// its qualifier or, failing that, a method of the same package. Calls that
+// match nothing or more than one function are left out.
+func buildCallGraph(funcDescriptions Func) string {
+	var descriptions []FunctionDescription
+	descriptions = append(descriptions, funcDescriptions.FunctionDescriptions...)
+	descriptions = append(descriptions, funcDescriptions.TestFunctionDescriptions...)
+
+	nodes := make([]callGraphNode, len(descriptions))
+	byName := make(map[string][]callGraphNode)
+	for i, desc := range descriptions {
+		node := callGraphNode{
+			id:       callGraphNodeID(desc),
+			pkg:      desc.Package,
+			name:     desc.Name,
+			isMethod: desc.IsMethod,
+		}
+		nodes[i] = node
+		byName[node.name] = append(byName[node.name], node)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph calls {\n")
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if !seen[node.id] {
+			seen[node.id] = true
+			sb.WriteString(fmt.Sprintf("  %s;\n", strconv.Quote(node.id)))
+		}
+	}
+
+	edges := make(map[string]bool)
+	for i, desc := range descriptions {
+		for _, call := range desc.Calls {
+			callee, ok := resolveCall(nodes[i], call, byName)
+			if !ok {
+				continue
+			}
+			edge := fmt.Sprintf("  %s -> %s;\n", strconv.Quote(nodes[i].id), strconv.Quote(callee.id))
+			if !edges[edge] {
+				edges[edge] = true
+				sb.WriteString(edge)
+			}
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func callGraphNodeID(desc FunctionDescription) string {
+	if !desc.IsMethod {
+		return desc.Package + "." + desc.Name
+	}
+	return desc.Package + "." + desc.ReceiverType + "." + desc.Name
+}
+
+func resolveCall(caller callGraphNode, call string, byName map[string][]callGraphNode) (callGraphNode, bool) {
+	qualifier, name := "", call
+	if i := strings.LastIndex(call, "."); i >= 0 {
+		qualifier, name = call[:i], call[i+1:]
+	}
+
+	var candidates []callGraphNode
+	for _, node := range byName[name] {
+		switch {
+		case qualifier == "":
+			if !node.isMethod && node.pkg == caller.pkg {
+				candidates = append(candidates, node)
+			}
+		case !node.isMethod && node.pkg == qualifier:
+			candidates = append(candidates, node)
+		}
+	}
+	if len(candidates) == 0 && qualifier != "" {
+		for _, node := range byName[name] {
+			if node.isMethod && node.pkg == caller.pkg {
+				candidates = append(candidates, node)
+			}
+		}
+	}
+
+	if len(candidates) != 1 {
+		return callGraphNode{}, false
+	}
+	return candidates[0], true
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildCallGraph(t *testing.T) {
+	src := `package app
+
+import "fmt"
+
+type Server struct{}
+
+func (s *Server) Start() { s.listen() }
+func (s *Server) listen() {}
+
+type Map[K comparable, V any] struct{}
+
+func (m *Map[K, V]) Get(k K) V { return m.lookup(k) }
+func (m *Map[K, V]) lookup(k K) V { var v V; return v }
+
+func Run() {
+	load()
+	fmt.Println("run")
+	store.Save()
+}
+
+func load() {}
+`
+	storeSrc := `package store
+
+func Save() {}
+`
+	funcs := parseTestSource(t, "app.go", src, Param{})
+	funcs.Merge(parseTestSource(t, "store/store.go", storeSrc, Param{}))
+	graph := buildCallGraph(funcs)
+
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{`"app.Run";`, true},
+		{`"app.Server.Start";`, true},
+		{`"app.Run" -> "app.load";`, true},
+		{`"app.Run" -> "store.Save";`, true},
+		{`"app.Server.Start" -> "app.Server.listen";`, true},
+		{`"app.Map.Get";`, true},
+		{`"app.Map.Get" -> "app.Map.lookup";`, true},
+		{`"fmt.Println"`, false},
+	}
+	for _, tt := range tests {
+		if got := strings.Contains(graph, tt.line); got != tt.want {
+			t.Errorf("graph contains %s: %t, want %t\n%s", tt.line, got, tt.want, graph)
+		}
+	}
+	if !strings.HasPrefix(graph, "digraph calls {\n") || !strings.HasSuffix(graph, "}\n") {
+		t.Errorf("graph is not a digraph:\n%s", graph)
+	}
+}
+
+func TestDotFormat(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"main.go": "package main\n\nfunc main() { run() }\n\nfunc run() {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatDot
+	})
+	b, err := os.ReadFile(filepath.Join(out, "calls.dot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edge := `"main.main" -> "main.run";`; !strings.Contains(string(b), edge) {
+		t.Errorf("calls.dot has no edge %s:\n%s", edge, b)
+	}
+}
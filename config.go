@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const configFileName = ".goparse.yaml"
+
+// fileConfig holds flag defaults read from a config file. Flags given on the
+// command line always win over the file.
+type fileConfig struct {
+	Exclude     []string `yaml:"exclude"`
+	Format      string   `yaml:"format"`
+	Workers     int      `yaml:"workers"`
+	IncludeBody *bool    `yaml:"include_body"`
+}
+
+// loadConfig reads the file named by --config, or .goparse.yaml in the first
+// project directory when it exists.
+func loadConfig(context *cli.Context) (fileConfig, error) {
+	path := context.String("config")
+	if path == "" {
+		projects := context.StringSlice("project")
+		if len(projects) == 0 || projects[0] == stdinProject {
+			return fileConfig{}, nil
+		}
+		project := projects[0]
+		if info, err := os.Stat(project); err != nil || !info.IsDir() {
+			return fileConfig{}, nil
+		}
+		path = filepath.Join(project, configFileName)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return fileConfig{}, nil
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fileConfig{}, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	var cfg fileConfig
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fileConfig{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (cfg fileConfig) apply(p *ProjectProcessor, context *cli.Context) {
+	if cfg.Exclude != nil && !context.IsSet("exclude") {
+		p.Exclude = cfg.Exclude
+	}
+	if cfg.Format != "" && !context.IsSet("format") {
+		p.Format = cfg.Format
+	}
+	if cfg.Workers != 0 && !context.IsSet("workers") {
+		p.Workers = cfg.Workers
+	}
+	if cfg.IncludeBody != nil && !context.IsSet("include-body") {
+		p.IncludeBody = *cfg.IncludeBody
+	}
+}
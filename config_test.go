@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestConfigFile(t *testing.T) {
+	files := map[string]string{
+		"main.go":     "package main\n\nfunc main() { go func() {}() }\n",
+		"gen/gen.go":  "package gen\n\nfunc Gen() {}\n",
+		"extra.yaml":  "include_body: true\n",
+		"broken.yaml": "include_bodies: true\n",
+	}
+	tests := []struct {
+		name         string
+		config       string
+		args         []string
+		wantBody     bool
+		wantFiles    []string
+		wantFunction []string
+		wantErr      string
+	}{
+		{name: "no config", wantBody: false},
+		{name: "include body", config: "include_body: true\n", wantBody: true},
+		{name: "flag wins", config: "include_body: true\n", args: []string{"--include-body=false"}, wantBody: false},
+		{name: "explicit config", args: []string{"--config", "extra.yaml"}, wantBody: true},
+		{name: "exclude", config: "exclude: [gen]\n", wantFunction: []string{"main"}},
+		{name: "exclude flag wins", config: "exclude: [gen]\n", args: []string{"--exclude", "nothing"}, wantFunction: []string{"Gen", "main"}},
+		{name: "format", config: "format: json\n", wantFiles: []string{defaultFunctionsFile}},
+		{name: "unknown key", args: []string{"--config", "broken.yaml"}, wantErr: "invalid config file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProject(t, files)
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(root, configFileName), []byte(tt.config), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			log.SetOutput(io.Discard)
+			defer log.SetOutput(os.Stderr)
+
+			out := t.TempDir()
+			args := []string{"parse", "--project", root, "--output", out}
+			for _, arg := range tt.args {
+				if strings.HasSuffix(arg, ".yaml") {
+					arg = filepath.Join(root, arg)
+				}
+				args = append(args, arg)
+			}
+			err := createCliApp().Run(args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantFiles != nil {
+				var names []string
+				entries, _ := os.ReadDir(out)
+				for _, entry := range entries {
+					names = append(names, entry.Name())
+				}
+				if !reflect.DeepEqual(names, tt.wantFiles) {
+					t.Errorf("files = %q, want %q", names, tt.wantFiles)
+				}
+			}
+			functions := readJSONOutput(t, out, defaultFunctionsFile).Functions
+			if tt.wantFunction != nil {
+				got := functionNames(functions)
+				sort.Strings(got)
+				if !reflect.DeepEqual(got, tt.wantFunction) {
+					t.Errorf("functions = %q, want %q", got, tt.wantFunction)
+				}
+			}
+			mainFunc := findFunction(t, functions, "main")
+			if got := mainFunc.Closures != nil; got != tt.wantBody {
+				t.Errorf("body included: %t, want %t", got, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+type DeclDescription struct {
+	Name     string `json:"name" yaml:"name"`
+	Kind     string `json:"kind" yaml:"kind"`
+	Type     string `json:"type" yaml:"type"`
+	Doc      string `json:"doc" yaml:"doc"`
+	Package  string `json:"package" yaml:"package"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Line     int    `json:"line" yaml:"line"`
+}
+
+// describeDecls records every package-level const and var. Within a const
+// block a spec without type or values repeats the previous spec, as with
+// iota, so it inherits the previous type.
+func describeDecls(p Param, file *ast.File, src source) []DeclDescription {
+	var decls []DeclDescription
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+			continue
+		}
+
+		var lastType string
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			typ := expr(vs.Type)
+			if gen.Tok == token.CONST {
+				if vs.Type == nil && len(vs.Values) == 0 {
+					typ = lastType
+				}
+				lastType = typ
+			}
+
+			for _, n := range vs.Names {
+				if n.Name == "_" || (p.ExportedOnly && !n.IsExported()) {
+					continue
+				}
+				decls = append(decls, DeclDescription{
+					Name:     n.Name,
+					Kind:     gen.Tok.String(),
+					Type:     typ,
+					Doc:      specDoc(gen, vs.Doc),
+					Package:  file.Name.Name,
+					FilePath: p.FilePath,
+					Line:     src.file.Position(n.Pos()).Line,
+				})
+			}
+		}
+	}
+	return decls
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribeDecls(t *testing.T) {
+	src := `package p
+
+type Weekday int
+
+// Days of the week.
+const (
+	Sunday Weekday = iota
+	Monday
+	// Tuesday comes after Monday.
+	Tuesday
+)
+
+const Untyped = 1
+
+// Timeout is how long to wait.
+var Timeout time.Duration = 5 * time.Second
+
+var _ = Untyped
+`
+	decls := parseTestSource(t, "decls.go", src, Param{}).DeclDescriptions
+	want := []DeclDescription{
+		{Name: "Sunday", Kind: "const", Type: "Weekday", Package: "p", FilePath: "decls.go", Line: 7},
+		{Name: "Monday", Kind: "const", Type: "Weekday", Package: "p", FilePath: "decls.go", Line: 8},
+		{Name: "Tuesday", Kind: "const", Type: "Weekday", Doc: "Tuesday comes after Monday.\n", Package: "p", FilePath: "decls.go", Line: 10},
+		{Name: "Untyped", Kind: "const", Package: "p", FilePath: "decls.go", Line: 13},
+		{Name: "Timeout", Kind: "var", Type: "time.Duration", Doc: "Timeout is how long to wait.\n", Package: "p", FilePath: "decls.go", Line: 16},
+	}
+	if !reflect.DeepEqual(decls, want) {
+		t.Errorf("decls = %+v\nwant %+v", decls, want)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import "sort"
+
+// DuplicateDescription lists every location of a function name declared in
+// more than one place. Methods are left out, since the receiver type already
+// tells them apart.
+type DuplicateDescription struct {
+	Name      string             `json:"name" yaml:"name"`
+	Locations []FunctionLocation `json:"locations" yaml:"locations"`
+}
+
+type FunctionLocation struct {
+	Package  string `json:"package" yaml:"package"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Line     int    `json:"line" yaml:"line"`
+}
+
+func findDuplicates(functions []FunctionDescription) []DuplicateDescription {
+	byName := make(map[string][]FunctionLocation)
+	for _, desc := range functions {
+		if desc.IsMethod || desc.Name == "init" || desc.Name == "_" {
+			continue
+		}
+		byName[desc.Name] = append(byName[desc.Name], FunctionLocation{
+			Package:  desc.Package,
+			FilePath: desc.FilePath,
+			Line:     desc.StartLine,
+		})
+	}
+
+	var duplicates []DuplicateDescription
+	for name, locations := range byName {
+		if len(locations) > 1 {
+			duplicates = append(duplicates, DuplicateDescription{Name: name, Locations: locations})
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return duplicates[i].Name < duplicates[j].Name
+	})
+	return duplicates
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	sources := []struct{ name, src string }{
+		{"json/parse.go", "package json\n\nfunc Parse() {}\n\nfunc init() {}\n"},
+		{"yaml/parse.go", "package yaml\n\nfunc init() {}\n\n// Parse parses.\nfunc Parse() {}\n\nfunc (d *Decoder) Decode() {}\n"},
+		{"xml/decode.go", "package xml\n\nfunc (d *Decoder) Decode() {}\n\nfunc Unique() {}\n"},
+	}
+	var funcs Func
+	for _, s := range sources {
+		funcs.Merge(parseTestSource(t, s.name, s.src, Param{}))
+	}
+	want := []DuplicateDescription{{
+		Name: "Parse",
+		Locations: []FunctionLocation{
+			{Package: "json", FilePath: "json/parse.go", Line: 3},
+			{Package: "yaml", FilePath: "yaml/parse.go", Line: 6},
+		},
+	}}
+	if got := findDuplicates(funcs.FunctionDescriptions); !reflect.DeepEqual(got, want) {
+		t.Errorf("duplicates = %+v\nwant %+v", got, want)
+	}
+	if got := findDuplicates(nil); got != nil {
+		t.Errorf("duplicates of nothing = %+v", got)
+	}
+}
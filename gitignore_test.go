@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestGitignoreRules(t *testing.T) {
+	var g gitignore
+	for _, rule := range []struct{ base, line string }{
+		{"", "# comment"},
+		{"", "build/"},
+		{"", "*.log"},
+		{"", "/root.go"},
+		{"", "docs/*.go"},
+		{"sub", "local.go"},
+		{"sub", "!keep.log"},
+	} {
+		if r, ok := parseIgnoreRule(rule.base, rule.line); ok {
+			g.rules = append(g.rules, r)
+		}
+	}
+
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"build", true, true},
+		{"a/build", true, true},
+		{"build", false, false},
+		{"x.log", false, true},
+		{"a/b/x.log", false, true},
+		{"root.go", false, true},
+		{"a/root.go", false, false},
+		{"docs/a.go", false, true},
+		{"a/docs/a.go", false, false},
+		{"sub/local.go", false, true},
+		{"local.go", false, false},
+		{"sub/keep.log", false, false},
+		{"other/keep.log", false, true},
+	}
+	for _, tt := range tests {
+		if got := g.ignored(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, %t) = %t, want %t", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*_gen.go", "api_gen.go", true},
+		{"*_gen.go", "a/b/api_gen.go", true},
+		{"*_gen.go", "api.go", false},
+		{"**/mocks/**", "mocks/store.go", true},
+		{"**/mocks/**", "a/b/mocks/c/store.go", true},
+		{"**/mocks/**", "a/mockstore.go", false},
+		{"a/*.go", "a/b.go", true},
+		{"a/*.go", "a/b/c.go", false},
+		{"/a/**", "a/b/c.go", true},
+	}
+	for _, tt := range tests {
+		got, err := matchGlob(tt.pattern, tt.name)
+		if err != nil {
+			t.Fatalf("matchGlob(%q, %q): %v", tt.pattern, tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %t, want %t", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
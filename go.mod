@@ -2,7 +2,11 @@ module parse
 
 go 1.21
 
-require github.com/urfave/cli/v2 v2.27.4
+require (
+	github.com/urfave/cli/v2 v2.27.4
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.4 // indirect
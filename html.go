@@ -0,0 +1,88 @@
+package main
+
+import (
+	"html/template"
+	"sort"
+	"strings"
+)
+
+type htmlPackage struct {
+	Name      string
+	Functions []FunctionDescription
+}
+
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Function report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+input { width: 100%; padding: .5em; margin-bottom: 1em; font-size: 1em; }
+section { margin-bottom: 1.5em; }
+details.function { margin: .25em 0 .25em 1em; }
+summary { cursor: pointer; }
+code, pre { font-family: monospace; }
+pre { background: #f5f5f5; padding: .5em; white-space: pre-wrap; }
+.location { color: #666; font-size: .9em; }
+</style>
+</head>
+<body>
+<h1>Function report</h1>
+<input id="search" type="search" placeholder="Filter functions by name or signature">
+{{range .}}<section class="package">
+<details open>
+<summary><h2 style="display:inline">{{.Name}}</h2></summary>
+{{range .Functions}}<details class="function" data-search="{{.Name}} {{.Signature}}">
+<summary><code>{{.Signature}}</code></summary>
+<p class="location">{{.FilePath}}:{{.StartLine}}</p>
+<pre>{{.Doc}}</pre>
+</details>
+{{end}}</details>
+</section>
+{{end}}<script>
+document.getElementById("search").addEventListener("input", function (e) {
+  var query = e.target.value.toLowerCase();
+  document.querySelectorAll("details.function").forEach(function (el) {
+    el.style.display = el.dataset.search.toLowerCase().indexOf(query) >= 0 ? "" : "none";
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+// groupByPackage returns the functions and test functions of every package,
+// ordered by package name.
+func groupByPackage(funcDescriptions Func) []htmlPackage {
+	byName := make(map[string]*htmlPackage)
+	var packages []*htmlPackage
+	for _, descriptions := range [][]FunctionDescription{funcDescriptions.FunctionDescriptions, funcDescriptions.TestFunctionDescriptions} {
+		for _, desc := range descriptions {
+			pkg, ok := byName[desc.Package]
+			if !ok {
+				pkg = &htmlPackage{Name: desc.Package}
+				byName[desc.Package] = pkg
+				packages = append(packages, pkg)
+			}
+			pkg.Functions = append(pkg.Functions, desc)
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	grouped := make([]htmlPackage, len(packages))
+	for i, pkg := range packages {
+		grouped[i] = *pkg
+	}
+	return grouped
+}
+
+func renderHTMLReport(funcDescriptions Func) (string, error) {
+	var sb strings.Builder
+	if err := htmlReport.Execute(&sb, groupByPackage(funcDescriptions)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	htmlScriptPattern = regexp.MustCompile(`(?s)<script>.*?</script>`)
+	htmlTagPattern    = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)[^>]*>`)
+)
+
+// htmlVoidElements are the elements of the report that have no end tag.
+var htmlVoidElements = map[string]bool{"meta": true, "input": true}
+
+// checkBalancedHTML reports the first element of doc that is not closed in
+// the order it was opened.
+func checkBalancedHTML(t *testing.T, doc string) {
+	t.Helper()
+	var open []string
+	for _, m := range htmlTagPattern.FindAllStringSubmatch(htmlScriptPattern.ReplaceAllString(doc, "<script></script>"), -1) {
+		closing, name := m[1] == "/", strings.ToLower(m[2])
+		switch {
+		case htmlVoidElements[name]:
+		case !closing:
+			open = append(open, name)
+		case len(open) == 0 || open[len(open)-1] != name:
+			t.Fatalf("unexpected </%s>, open elements %q", name, open)
+		default:
+			open = open[:len(open)-1]
+		}
+	}
+	if len(open) != 0 {
+		t.Fatalf("unclosed elements %q", open)
+	}
+}
+
+func TestRenderHTMLReport(t *testing.T) {
+	funcs := parseTestSource(t, "server/server.go", "package server\n\n// Serve serves <html>.\nfunc Serve() {}\n\nfunc stop() {}\n", Param{})
+	funcs.Merge(parseTestSource(t, "client/client.go", "package client\n\nfunc Dial(addr string) error { return nil }\n", Param{}))
+	funcs.Merge(parseTestSource(t, "client/client_test.go", "package client\n\nimport \"testing\"\n\nfunc TestDial(t *testing.T) {}\n", Param{}))
+
+	tests := []struct {
+		name  string
+		funcs Func
+		want  []string
+	}{
+		{"empty", Func{}, nil},
+		{"packages", funcs, []string{"client", "server"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := renderHTMLReport(tt.funcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkBalancedHTML(t, report)
+			if got := strings.Count(report, `<section class="package">`); got != len(tt.want) {
+				t.Errorf("report has %d package sections, want %d", got, len(tt.want))
+			}
+			last := -1
+			for _, pkg := range tt.want {
+				i := strings.Index(report, `<h2 style="display:inline">`+pkg+`</h2>`)
+				if i < 0 {
+					t.Errorf("report has no section for package %s", pkg)
+				} else if i < last {
+					t.Errorf("package %s is out of order", pkg)
+				}
+				last = i
+			}
+			if strings.Contains(report, "serves <html>") {
+				t.Error("doc comment is not escaped")
+			}
+		})
+	}
+}
+
+func TestHTMLFormat(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a/a.go": "package a\n\nfunc A() {}\n",
+		"b/b.go": "package b\n\nfunc B() {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatHTML
+	})
+	b, err := os.ReadFile(filepath.Join(out, "report.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBalancedHTML(t, string(b))
+	for _, want := range []string{`data-search="A func A()"`, `data-search="B func B()"`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("report.html has no %s", want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// IgnoredErrorDescription is a call whose error result looks dropped.
+type IgnoredErrorDescription struct {
+	Function string `json:"function" yaml:"function"`
+	Call     string `json:"call" yaml:"call"`
+	Reason   string `json:"reason" yaml:"reason"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Line     int    `json:"line" yaml:"line"`
+}
+
+const (
+	reasonBlankAssignment = "blank assignment"
+	reasonUncheckedCall   = "unchecked call"
+)
+
+// knownErrorFuncs are standard library functions that return only an error,
+// so calling them as a statement always drops it.
+var knownErrorFuncs = map[string]bool{
+	"os.Chdir":       true,
+	"os.Chmod":       true,
+	"os.Mkdir":       true,
+	"os.MkdirAll":    true,
+	"os.Remove":      true,
+	"os.RemoveAll":   true,
+	"os.Rename":      true,
+	"os.Setenv":      true,
+	"os.Unsetenv":    true,
+	"os.WriteFile":   true,
+	"json.Unmarshal": true,
+	"xml.Unmarshal":  true,
+}
+
+// errorFuncs returns the names of the functions declared in file whose last
+// result is an error, so calls to them can be checked without type
+// information. Methods are left out since their receiver is not resolved.
+func errorFuncs(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Type.Results.NumFields() == 0 {
+			continue
+		}
+		results := fn.Type.Results.List
+		if id, ok := results[len(results)-1].Type.(*ast.Ident); ok && id.Name == "error" {
+			names[fn.Name.Name] = true
+		}
+	}
+	return names
+}
+
+// ignoredErrors flags two patterns in the body of fn: a call whose last
+// result is assigned to _, and a call used as a statement to a function known
+// to return an error. A lone "_ = f()" is only flagged when f is known to
+// return an error, while "v, _ := f()" is flagged for any f.
+func ignoredErrors(fn *ast.FuncDecl, src source, filePath string, local map[string]bool) []IgnoredErrorDescription {
+	if fn.Body == nil {
+		return nil
+	}
+	var found []IgnoredErrorDescription
+	add := func(call *ast.CallExpr, reason string) {
+		found = append(found, IgnoredErrorDescription{
+			Function: fn.Name.Name,
+			Call:     src.text(call.Fun),
+			Reason:   reason,
+			FilePath: filePath,
+			Line:     src.file.Position(call.Pos()).Line,
+		})
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			if stmt.Tok != token.ASSIGN && stmt.Tok != token.DEFINE || len(stmt.Rhs) != 1 {
+				return true
+			}
+			call, ok := stmt.Rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			last, ok := stmt.Lhs[len(stmt.Lhs)-1].(*ast.Ident)
+			if ok && last.Name == "_" && (len(stmt.Lhs) > 1 || returnsErrorLast(call, local)) {
+				add(call, reasonBlankAssignment)
+			}
+		case *ast.ExprStmt:
+			if call, ok := stmt.X.(*ast.CallExpr); ok && returnsErrorLast(call, local) {
+				add(call, reasonUncheckedCall)
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func returnsErrorLast(call *ast.CallExpr, local map[string]bool) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return local[fun.Name]
+	case *ast.SelectorExpr:
+		return knownErrorFuncs[expr(fun)]
+	}
+	return false
+}
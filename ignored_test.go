@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIgnoredErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []IgnoredErrorDescription
+	}{
+		{"none", "\tif err := save(); err != nil {\n\t\treturn\n\t}\n", nil},
+		{"unchecked local", "\tsave()\n", []IgnoredErrorDescription{{Call: "save", Reason: reasonUncheckedCall, Line: 6}}},
+		{"unchecked stdlib", "\tos.Remove(\"x\")\n", []IgnoredErrorDescription{{Call: "os.Remove", Reason: reasonUncheckedCall, Line: 6}}},
+		{"blank local", "\t_ = save()\n", []IgnoredErrorDescription{{Call: "save", Reason: reasonBlankAssignment, Line: 6}}},
+		{"blank second result", "\tn, _ := count()\n\t_ = n\n", []IgnoredErrorDescription{{Call: "count", Reason: reasonBlankAssignment, Line: 6}}},
+		{"blank non-error", "\t_ = value()\n", nil},
+		{"unchecked non-error", "\tvalue()\n", nil},
+		{"method", "\tvar s S\n\ts.save()\n", nil},
+		{"closure", "\tfunc() { save() }()\n", []IgnoredErrorDescription{{Call: "save", Reason: reasonUncheckedCall, Line: 6}}},
+		{"empty result list", "\tnothing()\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package p\n\nimport \"os\"\n\nfunc run() {\n" + tt.body + "}\n" + `
+var _ = os.Remove
+
+func save() error { return nil }
+func count() (int, error) { return 0, nil }
+func value() int { return 0 }
+func nothing() () {}
+
+type S struct{}
+
+func (S) save() error { return nil }
+`
+			funcs := parseTestSource(t, "run.go", src, Param{})
+			var got []IgnoredErrorDescription
+			for _, desc := range funcs.IgnoredErrorDescriptions {
+				if desc.Function != "run" || desc.FilePath != "run.go" {
+					t.Errorf("finding %+v is not attributed to run in run.go", desc)
+				}
+				desc.Function, desc.FilePath = "", ""
+				got = append(got, desc)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ignored errors = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoredErrorsFile(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"main.go": "package main\n\nimport \"os\"\n\nfunc main() {\n\tos.Remove(\"tmp\")\n}\n",
+	}, nil)
+	b, err := os.ReadFile(filepath.Join(out, "ignored_errors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		IgnoredErrors []IgnoredErrorDescription `json:"ignored_errors"`
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	want := []IgnoredErrorDescription{{Function: "main", Call: "os.Remove", Reason: reasonUncheckedCall, FilePath: "main.go", Line: 6}}
+	if !reflect.DeepEqual(decoded.IgnoredErrors, want) {
+		t.Errorf("ignored errors = %+v, want %+v", decoded.IgnoredErrors, want)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+// buildIndex maps every function and test function name to the places it is
+// declared, for quick lookups without loading the full descriptions.
+func buildIndex(funcDescriptions Func) map[string][]FunctionLocation {
+	index := make(map[string][]FunctionLocation)
+	for _, descriptions := range [][]FunctionDescription{funcDescriptions.FunctionDescriptions, funcDescriptions.TestFunctionDescriptions} {
+		for _, desc := range descriptions {
+			index[desc.Name] = append(index[desc.Name], FunctionLocation{
+				Package:  desc.Package,
+				FilePath: desc.FilePath,
+				Line:     desc.StartLine,
+			})
+		}
+	}
+	return index
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildIndex(t *testing.T) {
+	sources := []struct{ name, src string }{
+		{"json/parse.go", "package json\n\nfunc Parse() {}\n\nfunc (d *Decoder) Decode() {}\n"},
+		{"yaml/parse.go", "package yaml\n\n// Parse parses.\nfunc Parse() {}\n"},
+		{"yaml/parse_test.go", "package yaml\n\nimport \"testing\"\n\nfunc TestParse(t *testing.T) {}\n"},
+	}
+	var funcs Func
+	for _, s := range sources {
+		funcs.Merge(parseTestSource(t, s.name, s.src, Param{}))
+	}
+	want := map[string][]FunctionLocation{
+		"Parse": {
+			{Package: "json", FilePath: "json/parse.go", Line: 3},
+			{Package: "yaml", FilePath: "yaml/parse.go", Line: 4},
+		},
+		"Decode":    {{Package: "json", FilePath: "json/parse.go", Line: 5}},
+		"TestParse": {{Package: "yaml", FilePath: "yaml/parse_test.go", Line: 5}},
+	}
+	if got := buildIndex(funcs); !reflect.DeepEqual(got, want) {
+		t.Errorf("index = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestIndexFile(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a.go": "package a\n\nfunc A() {}\n",
+	}, nil)
+	want := map[string][]FunctionLocation{"A": {{Package: "a", FilePath: "a.go", Line: 3}}}
+	if got := readJSONOutput(t, out, "index.json").Index; !reflect.DeepEqual(got, want) {
+		t.Errorf("index.json = %+v, want %+v", got, want)
+	}
+}
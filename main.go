@@ -1,21 +1,75 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"go/build"
+	"go/token"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/urfave/cli/v2"
 )
 
 type ProjectProcessor struct {
-	ProjectPath string
-	OutputPath  string
+	ProjectPaths     []string
+	OutputPath       string
+	IncludeBody      bool
+	Workers          int
+	Exclude          []string
+	IncludeVendor    bool
+	RespectGitignore bool
+	Stdout           bool
+	Format           string
+	ExportedOnly     bool
+	UndocumentedOnly bool
+	GroupMethods     bool
+	MarkVisibility   bool
+	MinComplexity    int
+	ExcludeFuncs     string
+	IncludeFuncs     string
+	Packages         []string
+	Recursive        bool
+	AllowEmpty       bool
+	MaxFileSize      int64
+	MaxChars         int
+	SplitByPackage   bool
+	Tags             []string
+	Progress         bool
+	SkipErrors       bool
+	FunctionsFile    string
+	TestsFile        string
+	DescriptionsFile string
+	DryRun           bool
+	CacheDir         string
+	Since            string
+	FollowSymlinks   bool
+	NoTests          bool
+	TestsOnly        bool
+	Gzip             bool
+	IncludeIgnored   bool
+	WritePartial     bool
+	Logger           *log.Logger
+
+	generatedAt time.Time
+	dryRunBytes int
 }
 
+const (
+	stdinProject  = "-"
+	stdinFileName = "stdin.go"
+)
+
 func main() {
 	app := createCliApp()
 	if err := app.Run(os.Args); err != nil {
@@ -34,50 +88,415 @@ func createCliApp() *cli.App {
 
 func createFlags() []cli.Flag {
 	return []cli.Flag{
-		&cli.StringFlag{
+		&cli.StringSliceFlag{
 			Name:     "project",
-			Usage:    "The path to the go project",
+			Usage:    "The path to the go project, a single .go file, or - to read source from stdin (repeatable)",
 			Required: true,
 		},
 		&cli.StringFlag{
-			Name:     "output",
-			Usage:    "The path to the output directory",
-			Required: true,
+			Name:  "config",
+			Usage: "Read flag defaults from the YAML file `FILE` (defaults to " + configFileName + " in the project directory)",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "The path to the output directory (required unless --stdout is set)",
+		},
+		&cli.StringFlag{
+			Name:  "functions-file",
+			Usage: "The file name of the functions JSON output",
+			Value: defaultFunctionsFile,
+		},
+		&cli.StringFlag{
+			Name:  "tests-file",
+			Usage: "The file name of the test functions JSON output",
+			Value: defaultTestsFile,
+		},
+		&cli.StringFlag{
+			Name:  "descriptions-file",
+			Usage: "The file name of the full description text output",
+			Value: defaultDescriptionsFile,
+		},
+		&cli.BoolFlag{
+			Name:  "gzip",
+			Usage: "Compress every output file with gzip and add a .gz extension (ignored with --stdout)",
+		},
+		&cli.BoolFlag{
+			Name:  "stdout",
+			Usage: "Write the output selected by --format to standard output instead of files (combined-json, with both functions and test functions, when --format is not set)",
+		},
+		&cli.Int64Flag{
+			Name:  "max-file-size",
+			Usage: "Skip Go files larger than this many bytes (0 means no limit)",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-errors",
+			Usage: "Log and skip unreadable files and directories instead of aborting the walk",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Only parse Go files that git reports as changed since the revision `REF`",
+		},
+		&cli.StringFlag{
+			Name:  "cache",
+			Usage: "Reuse parse results of unchanged files from the cache directory `DIR`",
+		},
+		&cli.BoolFlag{
+			Name:  "write-partial",
+			Usage: "Write the functions parsed so far when the parse is interrupted",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Parse the project and print a summary of the output on stderr without writing anything",
+		},
+		&cli.BoolFlag{
+			Name:  "quiet",
+			Usage: "Do not log per-file errors and skipped files; failures are still counted",
+		},
+		&cli.BoolFlag{
+			Name:  "progress",
+			Usage: "Report parsing progress on stderr",
+		},
+		&cli.BoolFlag{
+			Name:  "allow-empty",
+			Usage: "Succeed and write empty outputs when the project contains no Go files",
+		},
+		&cli.BoolFlag{
+			Name:  "exported-only",
+			Usage: "Only include exported functions and methods on exported types",
+		},
+		&cli.IntFlag{
+			Name:  "max-chars",
+			Usage: "Split the description text into numbered files of at most this many characters, never splitting a function (0 means no limit)",
+		},
+		&cli.BoolFlag{
+			Name:  "split-by-package",
+			Usage: "Write functions_<package>.json for each package instead of a single functions.json",
+		},
+		&cli.BoolFlag{
+			Name:  "group-methods",
+			Usage: "Group method descriptions under their receiver type in the text output",
+		},
+		&cli.BoolFlag{
+			Name:  "annotate-visibility",
+			Usage: "Mark each function name in the text output as [exported] or [unexported]",
+		},
+		&cli.StringFlag{
+			Name:  "exclude-funcs",
+			Usage: "Leave out functions whose name matches the regular expression `PATTERN`",
+		},
+		&cli.StringFlag{
+			Name:  "include-funcs",
+			Usage: "Only include functions whose name matches the regular expression `PATTERN` (--exclude-funcs wins)",
+		},
+		&cli.IntFlag{
+			Name:  "min-complexity",
+			Usage: "Only include functions whose cyclomatic complexity is at least `N`",
+		},
+		&cli.BoolFlag{
+			Name:  "undocumented-only",
+			Usage: "Only include functions without a doc comment",
+		},
+		&cli.StringSliceFlag{
+			Name:  "package",
+			Usage: "Only include functions from the package named `NAME` (repeatable)",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Write only one output, one of: " + strings.Join(formatNames(), ", ") + " (defaults to combined-json with --stdout and to all outputs otherwise)",
+		},
+		&cli.BoolFlag{
+			Name:  "docs-only",
+			Usage: "Only write the name, package and doc comment of each function (same as --format " + formatDocs + ")",
+		},
+		&cli.BoolFlag{
+			Name:  "include-body",
+			Usage: "Include the source of each function body in the description output",
+		},
+		&cli.IntFlag{
+			Name:  "workers",
+			Usage: "The number of files to parse in parallel",
+			Value: 1,
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "Skip files and directories whose path relative to the project matches the glob `PATTERN` (repeatable, supports **)",
+		},
+		&cli.BoolFlag{
+			Name:  "recursive",
+			Usage: "Walk subdirectories of the project; use --recursive=false to parse only the top directory",
+			Value: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "tags",
+			Usage: "Build tags to satisfy when evaluating build constraints, as with go build -tags",
+		},
+		&cli.BoolFlag{
+			Name:  "follow-symlinks",
+			Usage: "Walk into symlinked directories, visiting each directory only once",
+		},
+		&cli.BoolFlag{
+			Name:  "no-tests",
+			Usage: "Skip _test.go files",
+		},
+		&cli.BoolFlag{
+			Name:  "tests-only",
+			Usage: "Only parse _test.go files",
+		},
+		&cli.BoolFlag{
+			Name:  "include-ignored",
+			Usage: "Also parse files excluded by a //go:build ignore constraint",
+		},
+		&cli.BoolFlag{
+			Name:  "include-vendor",
+			Usage: "Parse files under vendor directories, which are skipped by default",
+		},
+		&cli.BoolFlag{
+			Name:  "respect-gitignore",
+			Usage: "Skip files and directories ignored by .gitignore files in the project",
 		},
 	}
 }
 
 func runApp(context *cli.Context) error {
+	cfg, err := loadConfig(context)
+	if err != nil {
+		return err
+	}
+
 	processor := ProjectProcessor{
-		ProjectPath: context.String("project"),
-		OutputPath:  context.String("output"),
+		ProjectPaths:     context.StringSlice("project"),
+		OutputPath:       context.String("output"),
+		IncludeBody:      context.Bool("include-body"),
+		Workers:          context.Int("workers"),
+		Exclude:          context.StringSlice("exclude"),
+		IncludeVendor:    context.Bool("include-vendor"),
+		RespectGitignore: context.Bool("respect-gitignore"),
+		Stdout:           context.Bool("stdout"),
+		Format:           context.String("format"),
+		ExportedOnly:     context.Bool("exported-only"),
+		UndocumentedOnly: context.Bool("undocumented-only"),
+		GroupMethods:     context.Bool("group-methods"),
+		MarkVisibility:   context.Bool("annotate-visibility"),
+		MinComplexity:    context.Int("min-complexity"),
+		ExcludeFuncs:     context.String("exclude-funcs"),
+		IncludeFuncs:     context.String("include-funcs"),
+		Packages:         context.StringSlice("package"),
+		Recursive:        context.Bool("recursive"),
+		AllowEmpty:       context.Bool("allow-empty"),
+		MaxFileSize:      context.Int64("max-file-size"),
+		MaxChars:         context.Int("max-chars"),
+		SplitByPackage:   context.Bool("split-by-package"),
+		Tags:             context.StringSlice("tags"),
+		Progress:         context.Bool("progress"),
+		SkipErrors:       context.Bool("skip-errors"),
+		FunctionsFile:    context.String("functions-file"),
+		TestsFile:        context.String("tests-file"),
+		DescriptionsFile: context.String("descriptions-file"),
+		DryRun:           context.Bool("dry-run"),
+		CacheDir:         context.String("cache"),
+		Since:            context.String("since"),
+		FollowSymlinks:   context.Bool("follow-symlinks"),
+		NoTests:          context.Bool("no-tests"),
+		TestsOnly:        context.Bool("tests-only"),
+		Gzip:             context.Bool("gzip"),
+		IncludeIgnored:   context.Bool("include-ignored"),
+		WritePartial:     context.Bool("write-partial"),
+		Logger:           log.Default(),
+	}
+	cfg.apply(&processor, context)
+	if context.Bool("quiet") {
+		processor.Logger = log.New(io.Discard, "", 0)
+	}
+	if context.Bool("docs-only") {
+		if context.IsSet("format") && processor.Format != formatDocs {
+			return fmt.Errorf("--docs-only cannot be combined with --format %s", processor.Format)
+		}
+		processor.Format = formatDocs
+	}
+	if processor.Stdout && processor.Format == "" {
+		processor.Format = formatCombinedJSON
 	}
-	return processor.Process()
+	ctx, stop := signal.NotifyContext(context.Context, os.Interrupt)
+	defer stop()
+	return processor.Process(ctx)
 }
 
-func (p *ProjectProcessor) Process() error {
+func (p *ProjectProcessor) Process(ctx context.Context) error {
+	if err := validateFormat(p.Format); err != nil {
+		return err
+	}
+	if err := p.validateFileNames(); err != nil {
+		return err
+	}
+	if p.NoTests && p.TestsOnly {
+		return errors.New("--no-tests and --tests-only cannot be combined")
+	}
 	if err := p.validatePaths(); err != nil {
 		return err
 	}
 
+	param := Param{
+		IncludeBody:      p.IncludeBody,
+		ExportedOnly:     p.ExportedOnly,
+		UndocumentedOnly: p.UndocumentedOnly,
+		GroupMethods:     p.GroupMethods,
+		MarkVisibility:   p.MarkVisibility,
+		MinComplexity:    p.MinComplexity,
+		Packages:         p.Packages,
+		Fset:             token.NewFileSet(),
+	}
+	var err error
+	if param.ExcludeFuncs, err = compileFuncPattern("exclude-funcs", p.ExcludeFuncs); err != nil {
+		return err
+	}
+	if param.IncludeFuncs, err = compileFuncPattern("include-funcs", p.IncludeFuncs); err != nil {
+		return err
+	}
+	if len(p.ProjectPaths) == 1 && p.ProjectPaths[0] == stdinProject {
+		return p.processStdin(param)
+	}
+
 	goFiles, err := p.findGoFiles()
 	if err != nil {
 		return fmt.Errorf("failed to find Go files: %w", err)
 	}
+	if p.Since != "" {
+		if goFiles, err = p.filterChanged(goFiles); err != nil {
+			return err
+		}
+	}
+	if len(goFiles) == 0 && !p.AllowEmpty {
+		if p.Since != "" {
+			return fmt.Errorf("no Go files under %s changed since %s", strings.Join(p.ProjectPaths, ", "), p.Since)
+		}
+		return fmt.Errorf("no Go files found under %s", strings.Join(p.ProjectPaths, ", "))
+	}
 
-	funcDescriptions := parseFunctions(goFiles)
+	var progress io.Writer
+	if p.Progress {
+		progress = os.Stderr
+	}
+	var cache *parseCache
+	if p.CacheDir != "" {
+		if cache, err = newParseCache(p.CacheDir, param, p.logger()); err != nil {
+			return err
+		}
+	}
+	funcDescriptions, parsed, parseErrs := parseFunctions(ctx, goFiles, param, p.Workers, progress, cache)
+	if cache != nil && progress != nil {
+		fmt.Fprintf(progress, "loaded %d/%d files from cache\n", cache.hits.Load(), len(goFiles))
+	}
+	if parsed < len(goFiles) {
+		return p.writePartial(ctx, funcDescriptions, parsed, len(goFiles), parseErrs)
+	}
+	funcDescriptions.Sort()
 	if err := p.writeOutputFiles(funcDescriptions); err != nil {
 		return err
 	}
+	p.logSummary(funcDescriptions, len(goFiles)-len(parseErrs), len(parseErrs))
+
+	if len(parseErrs) > 0 {
+		for _, err := range parseErrs {
+			p.logger().Print(err)
+		}
+		return fmt.Errorf("failed to parse %d of %d files", len(parseErrs), len(goFiles))
+	}
 
 	return nil
 }
 
+// writePartial handles a parse that was cancelled before every file was
+// read. The functions parsed so far are only written with WritePartial.
+func (p *ProjectProcessor) writePartial(ctx context.Context, funcDescriptions Func, parsed, total int, parseErrs []error) error {
+	for _, err := range parseErrs {
+		p.logger().Print(err)
+	}
+	if p.WritePartial {
+		funcDescriptions.Sort()
+		if err := p.writeOutputFiles(funcDescriptions); err != nil {
+			return err
+		}
+		p.logSummary(funcDescriptions, parsed-len(parseErrs), len(parseErrs))
+	}
+	return fmt.Errorf("parse interrupted after %d of %d files: %w", parsed, total, ctx.Err())
+}
+
+func compileFuncPattern(flag, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s pattern: %w", flag, err)
+	}
+	return re, nil
+}
+
+func (p *ProjectProcessor) processStdin(param Param) error {
+	code, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read source from stdin: %w", err)
+	}
+
+	var funcDescriptions Func
+	if err := funcDescriptions.ParseSource(stdinFileName, string(code), param); err != nil {
+		return err
+	}
+	if err := p.writeOutputFiles(funcDescriptions); err != nil {
+		return err
+	}
+	p.logSummary(funcDescriptions, 1, 0)
+	return nil
+}
+
+// logSummary reports what was parsed once the output is written. Like the
+// dry run summary it goes to stderr even when the logger is silenced, which
+// is why a dry run does not print this one too.
+func (p *ProjectProcessor) logSummary(funcDescriptions Func, parsed, failed int) {
+	if p.DryRun {
+		return
+	}
+	p.writeSummary(os.Stderr, funcDescriptions, parsed, failed)
+}
+
+func (p *ProjectProcessor) writeSummary(w io.Writer, funcDescriptions Func, parsed, failed int) {
+	fmt.Fprintf(w, "Parsed %d files: %d functions, %d test functions, %d errors\n",
+		parsed, len(funcDescriptions.FunctionDescriptions), len(funcDescriptions.TestFunctionDescriptions), failed)
+}
+
+func (p *ProjectProcessor) logger() *log.Logger {
+	if p.Logger == nil {
+		return log.Default()
+	}
+	return p.Logger
+}
+
 func (p *ProjectProcessor) validatePaths() error {
-	if _, err := os.Stat(p.ProjectPath); os.IsNotExist(err) {
-		return fmt.Errorf("project path does not exist: %v", err)
+	if len(p.ProjectPaths) == 0 {
+		return errors.New("a project path is required")
+	}
+	for _, root := range p.ProjectPaths {
+		if root == stdinProject {
+			if len(p.ProjectPaths) > 1 {
+				return errors.New("reading from stdin cannot be combined with other project paths")
+			}
+			continue
+		}
+		info, err := os.Stat(root)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("project path does not exist: %v", err)
+		}
+		if err == nil && !info.IsDir() && !strings.HasSuffix(info.Name(), ".go") {
+			return fmt.Errorf("project path %s is neither a directory nor a .go file", root)
+		}
 	}
 
+	if p.Stdout || p.DryRun {
+		return nil
+	}
+	if p.OutputPath == "" {
+		return errors.New("an output directory is required unless --stdout is set")
+	}
 	if err := os.MkdirAll(p.OutputPath, 0755); err != nil {
 		return fmt.Errorf("error creating output directory: %v", err)
 	}
@@ -85,16 +504,96 @@ func (p *ProjectProcessor) validatePaths() error {
 	return nil
 }
 
-func (p *ProjectProcessor) findGoFiles() ([]string, error) {
-	var goFiles []string
+// projectFile is a Go file together with the project root it was found under.
+type projectFile struct {
+	path string
+	root string
+}
 
-	err := filepath.Walk(p.ProjectPath, func(path string, info os.FileInfo, err error) error {
+// findGoFiles collects the Go files of every project root. A file reachable
+// from more than one root is only kept for the first of them.
+func (p *ProjectProcessor) findGoFiles() ([]projectFile, error) {
+	var goFiles []projectFile
+	seen := make(map[string]bool)
+	for _, root := range p.ProjectPaths {
+		root = filepath.Clean(root)
+		paths, err := p.findRootGoFiles(root)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		for _, path := range paths {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[abs] {
+				seen[abs] = true
+				goFiles = append(goFiles, projectFile{path: path, root: root})
+			}
+		}
+	}
+	return goFiles, nil
+}
+
+func (p *ProjectProcessor) findRootGoFiles(root string) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat project path: %w", err)
+	}
+	filter := p.newFileFilter()
+	if !info.IsDir() {
+		include, err := filter.includes(root, info)
+		if err != nil || !include {
+			return nil, err
+		}
+		return []string{root}, nil
+	}
+
+	var goFiles []string
+	var ignore gitignore
+	err = p.walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return p.handleWalkError(path, err)
+		}
+
+		if info.IsDir() && path != root && (!p.Recursive || p.isSkippedDir(info.Name())) {
+			return filepath.SkipDir
+		}
+
+		if p.RespectGitignore {
+			ignored, err := p.isGitignored(&ignore, root, path, info)
+			if err != nil {
+				return err
+			}
+			if ignored {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if path != root {
+			excluded, err := p.isExcluded(root, path)
+			if err != nil {
+				return err
+			}
+			if excluded {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
 		}
 
 		if !info.IsDir() && strings.HasSuffix(info.Name(), ".go") && !strings.Contains(info.Name(), "generated") {
-			goFiles = append(goFiles, path)
+			include, err := filter.includes(path, info)
+			if err != nil {
+				return p.handleWalkError(path, err)
+			}
+			if include {
+				goFiles = append(goFiles, path)
+			}
 		}
 
 		return nil
@@ -107,70 +606,203 @@ func (p *ProjectProcessor) findGoFiles() ([]string, error) {
 	return goFiles, nil
 }
 
-func parseFunctions(goFiles []string) Func {
-	funcDescriptions := Func{}
-	for _, goFile := range goFiles {
-		param := Param{
-			FilePath:    goFile,
-			FileName:    filepath.Base(goFile),
-			IncludeBody: false,
-		}
-		funcDescriptions.ParseFunctions(param)
-	}
-	return funcDescriptions
+// fileFilter applies the flags that select Go files by their name, build
+// constraints and size. It is shared by the walk and a project that is a
+// single file.
+type fileFilter struct {
+	p             *ProjectProcessor
+	buildContext  build.Context
+	ignoreContext build.Context
 }
 
-func (p *ProjectProcessor) writeOutputFiles(funcDescriptions Func) error {
-	allDescriptions := combineDescriptions(funcDescriptions)
-	if err := p.writeToFile(allDescriptions, "all_function_descriptions.txt"); err != nil {
-		return fmt.Errorf("failed to write descriptions to file: %w", err)
-	}
+func (p *ProjectProcessor) newFileFilter() fileFilter {
+	buildContext := build.Default
+	buildContext.BuildTags = p.Tags
+	// Files constrained to the "ignore" tag, such as generators, are skipped
+	// like any other mismatch. IncludeIgnored matches them again with it set.
+	ignoreContext := buildContext
+	ignoreContext.BuildTags = append(slices.Clip(p.Tags), "ignore")
+	return fileFilter{p: p, buildContext: buildContext, ignoreContext: ignoreContext}
+}
 
-	if err := p.writeJSONFile(funcDescriptions.TestFunctionDescriptions, "test_functions.json"); err != nil {
-		return fmt.Errorf("failed to write test functions to file: %w", err)
+func (f fileFilter) includes(path string, info os.FileInfo) (bool, error) {
+	p := f.p
+	if isTest := isTestFileName(info.Name()); (isTest && p.NoTests) || (!isTest && p.TestsOnly) {
+		return false, nil
 	}
-
-	if err := p.writeJSONFile(funcDescriptions.FunctionDescriptions, "functions.json"); err != nil {
-		return fmt.Errorf("failed to write functions to file: %w", err)
+	match, err := f.buildContext.MatchFile(filepath.Dir(path), info.Name())
+	if err == nil && !match && p.IncludeIgnored {
+		match, err = f.ignoreContext.MatchFile(filepath.Dir(path), info.Name())
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate build constraints: %w", err)
 	}
+	if !match {
+		return false, nil
+	}
+	if p.MaxFileSize > 0 && info.Size() > p.MaxFileSize {
+		p.logger().Printf("Skipping %s: %d bytes exceeds the maximum file size of %d bytes", path, info.Size(), p.MaxFileSize)
+		return false, nil
+	}
+	return true, nil
+}
 
+// handleWalkError aborts the walk with err unless SkipErrors is set, in which
+// case the offending path is logged and skipped.
+func (p *ProjectProcessor) handleWalkError(path string, err error) error {
+	if !p.SkipErrors {
+		return err
+	}
+	p.logger().Printf("Skipping %s: %v", path, err)
 	return nil
 }
 
-func combineDescriptions(funcDescriptions Func) string {
-	var allDescriptions strings.Builder
-	allDescriptions.WriteString("#### This is detailed description of all functions in the project its references\n")
-	for _, desc := range funcDescriptions.FullDescriptions {
-		allDescriptions.WriteString(desc)
+// isSkippedDir follows the go tool: testdata is never part of a package and
+// vendored dependencies are only wanted on request.
+func (p *ProjectProcessor) isSkippedDir(name string) bool {
+	switch name {
+	case "testdata":
+		return true
+	case "vendor":
+		return !p.IncludeVendor
+	}
+	return false
+}
+
+func (p *ProjectProcessor) isGitignored(ignore *gitignore, root, path string, info os.FileInfo) (bool, error) {
+	if path != root {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return false, err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return true, nil
+		}
+		if ignore.ignored(filepath.ToSlash(rel), info.IsDir()) {
+			return true, nil
+		}
+	}
+
+	if info.IsDir() {
+		if err := ignore.load(root, path); err != nil {
+			return false, err
+		}
 	}
-	return allDescriptions.String()
+	return false, nil
 }
 
-func (p *ProjectProcessor) writeToFile(content, filename string) error {
-	fullPath := filepath.Join(p.OutputPath, filename)
-	file, err := os.Create(fullPath)
+func (p *ProjectProcessor) isExcluded(root, path string) (bool, error) {
+	rel, err := filepath.Rel(root, path)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return false, err
 	}
-	defer func(file *os.File) {
-		err := file.Close()
+	rel = filepath.ToSlash(rel)
+
+	for _, pattern := range p.Exclude {
+		matched, err := matchGlob(pattern, rel)
 		if err != nil {
-			log.Printf("failed to close file: %v", err)
+			return false, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
 		}
-	}(file)
+	}
+	return false, nil
+}
 
-	_, err = file.WriteString(content)
-	if err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
+// parseFunctions parses every file with a copy of base whose FilePath,
+// FileName and Root are set to that file. A "parsed N/M files" line is written to
+// progress, when it is not nil, after each file. Once ctx is done no further
+// files are started, so the returned count of parsed files may be less than
+// len(goFiles).
+func parseFunctions(ctx context.Context, goFiles []projectFile, base Param, workers int, progress io.Writer, cache *parseCache) (Func, int, []error) {
+	if workers < 1 {
+		workers = 1
 	}
 
-	return nil
+	type fileResult struct {
+		path  string
+		funcs Func
+		err   error
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []fileResult
+	)
+	files := make(chan projectFile)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for goFile := range files {
+				if ctx.Err() != nil {
+					continue
+				}
+				param := base
+				param.FilePath = goFile.path
+				param.FileName = filepath.Base(goFile.path)
+				param.Root = goFile.root
+				funcs, err := parseFile(ctx, param, cache)
+				if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+					continue
+				}
+
+				mu.Lock()
+				results = append(results, fileResult{path: goFile.path, funcs: funcs, err: err})
+				if progress != nil {
+					fmt.Fprintf(progress, "parsed %d/%d files\n", len(results), len(goFiles))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, goFile := range goFiles {
+		select {
+		case files <- goFile:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(files)
+	wg.Wait()
+
+	// Workers finish in any order, so merge by file path to keep the output reproducible.
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].path < results[j].path
+	})
+
+	funcDescriptions := Func{}
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		funcDescriptions.Merge(r.funcs)
+	}
+	return funcDescriptions, len(results), errs
 }
 
-func (p *ProjectProcessor) writeJSONFile(data interface{}, filename string) error {
-	b, err := json.Marshal(data)
+func parseFile(ctx context.Context, param Param, cache *parseCache) (Func, error) {
+	var funcs Func
+	if cache == nil {
+		return funcs, funcs.ParseFunctions(ctx, param)
+	}
+
+	info, err := os.Stat(param.FilePath)
 	if err != nil {
-		return fmt.Errorf("failed to marshal data: %w", err)
+		return funcs, funcs.ParseFunctions(ctx, param)
+	}
+	if cached, ok := cache.load(param, info); ok {
+		return cached, nil
+	}
+	if err := funcs.ParseFunctions(ctx, param); err != nil {
+		return funcs, err
 	}
-	return p.writeToFile(string(b), filename)
+	cache.store(param, info, funcs)
+	return funcs, nil
 }
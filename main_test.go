@@ -0,0 +1,1030 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writeProject creates files, keyed by slash-separated paths, in a new
+// temporary directory and returns that directory.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// newTestProcessor returns a processor for roots with the flag defaults,
+// writing into a temporary directory and discarding its logs.
+func newTestProcessor(t *testing.T, roots ...string) *ProjectProcessor {
+	t.Helper()
+	return &ProjectProcessor{
+		ProjectPaths: roots,
+		OutputPath:   t.TempDir(),
+		Workers:      1,
+		Recursive:    true,
+		Logger:       log.New(io.Discard, "", 0),
+	}
+}
+
+func TestParseFunctionsCollectsErrors(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"good.go":   "package p\n\nfunc Good() {}\n",
+		"broken.go": "package p\n\nfunc Broken( {\n",
+	})
+	p := newTestProcessor(t, root)
+	goFiles, err := p.findGoFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	funcs, parsed, errs := parseFunctions(context.Background(), goFiles, Param{}, 1, nil, nil)
+	if parsed != 2 {
+		t.Errorf("parsed %d files, want 2", parsed)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(errs), errs)
+	}
+	if len(funcs.FunctionDescriptions) != 1 || funcs.FunctionDescriptions[0].Name != "Good" {
+		t.Errorf("functions = %+v, want only Good", funcs.FunctionDescriptions)
+	}
+
+	if err := p.Process(context.Background()); err == nil {
+		t.Error("Process succeeded with an unparseable file")
+	}
+}
+
+var generatedAtPattern = regexp.MustCompile(`"generated_at":"[^"]*"`)
+
+// readOutputs returns the content of every file in dir by name, with the
+// generation timestamps blanked out so runs can be compared.
+func readOutputs(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := make(map[string]string)
+	for _, entry := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[entry.Name()] = generatedAtPattern.ReplaceAllString(string(b), `"generated_at":""`)
+	}
+	return outputs
+}
+
+// manyFilesProject returns a project of n files spread over a few packages.
+func manyFilesProject(t testing.TB, n int) string {
+	root := t.TempDir()
+	for i := 0; i < n; i++ {
+		pkg := fmt.Sprintf("pkg%d", i%4)
+		src := fmt.Sprintf("package %s\n\n// F%d is function %d.\nfunc F%d(a int) int {\n\tif a > %d {\n\t\treturn a\n\t}\n\treturn F%d(a + 1)\n}\n", pkg, i, i, i, i, i)
+		if err := os.MkdirAll(filepath.Join(root, pkg), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, pkg, fmt.Sprintf("f%d.go", i)), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestWorkersProduceIdenticalOutput(t *testing.T) {
+	root := manyFilesProject(t, 40)
+	var outputs []map[string]string
+	for _, workers := range []int{1, 8} {
+		p := newTestProcessor(t, root)
+		p.Workers = workers
+		if err := p.Process(context.Background()); err != nil {
+			t.Fatalf("workers=%d: %v", workers, err)
+		}
+		outputs = append(outputs, readOutputs(t, p.OutputPath))
+	}
+	if len(outputs[0]) == 0 {
+		t.Fatal("no output files written")
+	}
+	if !reflect.DeepEqual(outputs[0], outputs[1]) {
+		for name, content := range outputs[0] {
+			if outputs[1][name] != content {
+				t.Errorf("%s differs between --workers 1 and --workers 8", name)
+			}
+		}
+	}
+}
+
+func TestIncludeBody(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"add.go": "package p\n\n// Add adds.\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+	})
+	var outputs []map[string]string
+	for _, includeBody := range []bool{false, true} {
+		p := newTestProcessor(t, root)
+		p.IncludeBody = includeBody
+		if err := p.Process(context.Background()); err != nil {
+			t.Fatalf("include-body=%v: %v", includeBody, err)
+		}
+		outputs = append(outputs, readOutputs(t, p.OutputPath))
+	}
+	without, with := outputs[0], outputs[1]
+
+	body := "####Function Body of function Add\n```go\nfunc Add(a, b int) int {\n\treturn a + b\n}\n```\n"
+	text := with[defaultDescriptionsFile]
+	if !strings.Contains(text, body) {
+		t.Errorf("%s does not contain the fenced body:\n%s", defaultDescriptionsFile, text)
+	}
+	if got := strings.Replace(text, body, "", 1); got != without[defaultDescriptionsFile] {
+		t.Errorf("%s differs by more than the body:\n%s\nwant\n%s", defaultDescriptionsFile, got, without[defaultDescriptionsFile])
+	}
+
+	if without[defaultFunctionsFile] == "" {
+		t.Fatalf("%s was not written", defaultFunctionsFile)
+	}
+	for name, content := range without {
+		if name != defaultDescriptionsFile && with[name] != content {
+			t.Errorf("%s differs with --include-body:\n%s\nwant\n%s", name, with[name], content)
+		}
+	}
+	if len(with) != len(without) {
+		t.Errorf("--include-body wrote %d files, want %d", len(with), len(without))
+	}
+}
+
+func BenchmarkParseFunctions(b *testing.B) {
+	root := manyFilesProject(b, 200)
+	p := &ProjectProcessor{ProjectPaths: []string{root}, Recursive: true}
+	goFiles, err := p.findGoFiles()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				parseFunctions(context.Background(), goFiles, Param{Fset: token.NewFileSet()}, workers, nil, nil)
+			}
+		})
+	}
+}
+
+// cancelAfter is a progress writer that cancels a parse once n files have
+// been reported.
+type cancelAfter struct {
+	n      int
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) Write(b []byte) (int, error) {
+	if c.n--; c.n == 0 {
+		c.cancel()
+	}
+	return len(b), nil
+}
+
+func TestParseFunctionsCancel(t *testing.T) {
+	root := manyFilesProject(t, 200)
+	p := &ProjectProcessor{ProjectPaths: []string{root}, Recursive: true}
+	goFiles, err := p.findGoFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		workers              int
+		minParsed, maxParsed int
+	}{
+		{1, 3, 3},
+		{4, 3, 6},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("workers=%d", tt.workers), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			funcs, parsed, errs := parseFunctions(ctx, goFiles, Param{Fset: token.NewFileSet()}, tt.workers, &cancelAfter{n: 3, cancel: cancel}, nil)
+			if parsed < tt.minParsed || parsed > tt.maxParsed {
+				t.Errorf("parsed %d of %d files, want %d to %d", parsed, len(goFiles), tt.minParsed, tt.maxParsed)
+			}
+			if len(errs) != 0 {
+				t.Errorf("errs = %v", errs)
+			}
+			if got := len(funcs.FunctionDescriptions); got != parsed {
+				t.Errorf("got %d functions from %d files", got, parsed)
+			}
+		})
+	}
+}
+
+func TestProcessCancelled(t *testing.T) {
+	root := manyFilesProject(t, 10)
+	tests := []struct {
+		name         string
+		writePartial bool
+	}{
+		{"default", false},
+		{"write partial", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			p := newTestProcessor(t, root)
+			p.WritePartial = tt.writePartial
+			err := p.Process(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("err = %v, want %v", err, context.Canceled)
+			}
+			if !strings.Contains(err.Error(), "parse interrupted after 0 of 10 files") {
+				t.Errorf("err = %v, want the parsed files counted", err)
+			}
+			_, statErr := os.Stat(filepath.Join(p.OutputPath, defaultFunctionsFile))
+			if written := statErr == nil; written != tt.writePartial {
+				t.Errorf("%s written: %t, want %t", defaultFunctionsFile, written, tt.writePartial)
+			}
+		})
+	}
+}
+
+// relGoFiles returns the files findGoFiles reports for p, as sorted
+// slash-separated paths relative to root.
+func relGoFiles(t *testing.T, p *ProjectProcessor, root string) []string {
+	t.Helper()
+	goFiles, err := p.findGoFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, goFile := range goFiles {
+		rel, err := filepath.Rel(root, goFile.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestExclude(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"main.go":             "package main\n",
+		"api_gen.go":          "package main\n",
+		"mocks/store.go":      "package mocks\n",
+		"store/mocks/fake.go": "package mocks\n",
+		"store/store.go":      "package store\n",
+		"store/store_gen.go":  "package store\n",
+	})
+	tests := []struct {
+		name    string
+		exclude []string
+		want    []string
+	}{
+		{"none", nil, []string{"api_gen.go", "main.go", "mocks/store.go", "store/mocks/fake.go", "store/store.go", "store/store_gen.go"}},
+		{"directory", []string{"mocks"}, []string{"api_gen.go", "main.go", "store/store.go", "store/store_gen.go"}},
+		{"any depth", []string{"**/mocks/**"}, []string{"api_gen.go", "main.go", "store/store.go", "store/store_gen.go"}},
+		{"base name", []string{"*_gen.go"}, []string{"main.go", "mocks/store.go", "store/mocks/fake.go", "store/store.go"}},
+		{"anchored", []string{"store/*"}, []string{"api_gen.go", "main.go", "mocks/store.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.Exclude = tt.exclude
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipVendorAndTestdata(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"main.go":                   "package main\n",
+		"vendor/dep/dep.go":         "package dep\n",
+		"testdata/fixture.go":       "package fixture\n",
+		"internal/testdata/more.go": "package more\n",
+	})
+	tests := []struct {
+		name          string
+		includeVendor bool
+		want          []string
+	}{
+		{"default", false, []string{"main.go"}},
+		{"include vendor", true, []string{"main.go", "vendor/dep/dep.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.IncludeVendor = tt.includeVendor
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespectGitignore(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		".gitignore":         "build/\n*.tmp.go\n",
+		"main.go":            "package main\n",
+		"scratch.tmp.go":     "package main\n",
+		"build/out.go":       "package build\n",
+		"lib/.gitignore":     "gen/\n!keep.tmp.go\n",
+		"lib/lib.go":         "package lib\n",
+		"lib/keep.tmp.go":    "package lib\n",
+		"lib/gen/gen.go":     "package gen\n",
+		"other/gen/other.go": "package gen\n",
+	})
+	tests := []struct {
+		name    string
+		respect bool
+		want    []string
+	}{
+		{"ignored by default", false, []string{"build/out.go", "lib/gen/gen.go", "lib/keep.tmp.go", "lib/lib.go", "main.go", "other/gen/other.go", "scratch.tmp.go"}},
+		{"respected", true, []string{"lib/keep.tmp.go", "lib/lib.go", "main.go", "other/gen/other.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.RespectGitignore = tt.respect
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// capture redirects *f, os.Stdout or os.Stderr, to a pipe while fn runs and
+// returns what was written.
+func capture(t *testing.T, f **os.File, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := *f
+	*f = w
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	defer func() {
+		*f = saved
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestSingleFileProject(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":      "package p\n\nfunc A() {}\n",
+		"b.go":      "package p\n\nfunc B() {}\n",
+		"notes.txt": "not go\n",
+	})
+
+	p := newTestProcessor(t, filepath.Join(root, "a.go"))
+	if err := p.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	functions := readJSONOutput(t, p.OutputPath, defaultFunctionsFile).Functions
+	if got := functionNames(functions); !reflect.DeepEqual(got, []string{"A"}) {
+		t.Errorf("functions = %q, want only A", got)
+	}
+	if functions[0].FilePath != "a.go" {
+		t.Errorf("file path = %q, want a.go", functions[0].FilePath)
+	}
+
+	p = newTestProcessor(t, filepath.Join(root, "notes.txt"))
+	err := p.Process(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "neither a directory nor a .go file") {
+		t.Errorf("Process(notes.txt) error = %v", err)
+	}
+}
+
+func TestRecursive(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"top.go":             "package top\n",
+		"nested/nested.go":   "package nested\n",
+		"nested/deep/dee.go": "package deep\n",
+	})
+	tests := []struct {
+		recursive bool
+		want      []string
+	}{
+		{true, []string{"nested/deep/dee.go", "nested/nested.go", "top.go"}},
+		{false, []string{"top.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("recursive=%t", tt.recursive), func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.Recursive = tt.recursive
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStdinProject(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = saved }()
+	go func() {
+		io.WriteString(w, "package piped\n\nfunc FromStdin() {}\n")
+		w.Close()
+	}()
+
+	p := newTestProcessor(t, stdinProject)
+	if err := p.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	functions := readJSONOutput(t, p.OutputPath, defaultFunctionsFile).Functions
+	if len(functions) != 1 || functions[0].Name != "FromStdin" || functions[0].FilePath != stdinFileName {
+		t.Errorf("functions = %+v, want FromStdin in %s", functions, stdinFileName)
+	}
+
+	p = newTestProcessor(t, stdinProject, t.TempDir())
+	if err := p.Process(context.Background()); err == nil {
+		t.Error("reading stdin together with another project succeeded")
+	}
+}
+
+func TestOutputOrderIsStable(t *testing.T) {
+	root := manyFilesProject(t, 12)
+	p := newTestProcessor(t, root)
+	goFiles, err := p.findGoFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want string
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5; i++ {
+		shuffled := slices.Clone(goFiles)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		funcs, _, errs := parseFunctions(context.Background(), shuffled, Param{}, 3, nil, nil)
+		if len(errs) > 0 {
+			t.Fatal(errs)
+		}
+		funcs.Sort()
+		b, err := json.Marshal(funcs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			want = string(b)
+		} else if string(b) != want {
+			t.Fatalf("output differs for shuffled file order %d", i)
+		}
+	}
+}
+
+func TestSortFunctionDescriptions(t *testing.T) {
+	descriptions := []FunctionDescription{
+		{Name: "b2", FilePath: "b.go", StartLine: 20},
+		{Name: "a9", FilePath: "a.go", StartLine: 9},
+		{Name: "b1", FilePath: "b.go", StartLine: 3},
+		{Name: "a1", FilePath: "a.go", StartLine: 1},
+	}
+	sortFunctionDescriptions(descriptions)
+	if got, want := functionNames(descriptions), []string{"a1", "a9", "b1", "b2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+}
+
+func TestNoGoFiles(t *testing.T) {
+	root := writeProject(t, map[string]string{"README.md": "# nothing here\n"})
+	tests := []struct {
+		allowEmpty bool
+		wantErr    bool
+	}{
+		{false, true},
+		{true, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("allow-empty=%t", tt.allowEmpty), func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.AllowEmpty = tt.allowEmpty
+			err := p.Process(context.Background())
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "no Go files found under "+root) {
+					t.Errorf("error = %v, want no Go files found under %s", err, root)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(p.OutputPath, defaultFunctionsFile)); err != nil {
+				t.Errorf("no empty output written: %v", err)
+			}
+		})
+	}
+}
+
+func TestMaxFileSize(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"small.go": "package p\n",
+		"large.go": "package p\n\n" + strings.Repeat("// padding\n", 100),
+	})
+	tests := []struct {
+		maxFileSize int64
+		want        []string
+		wantLog     bool
+	}{
+		{0, []string{"large.go", "small.go"}, false},
+		{100, []string{"small.go"}, true},
+		{10000, []string{"large.go", "small.go"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max=%d", tt.maxFileSize), func(t *testing.T) {
+			var logs strings.Builder
+			p := newTestProcessor(t, root)
+			p.MaxFileSize = tt.maxFileSize
+			p.Logger = log.New(&logs, "", 0)
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+			if got := strings.Contains(logs.String(), "Skipping "+filepath.Join(root, "large.go")); got != tt.wantLog {
+				t.Errorf("logged the skipped file: %t, want %t\n%s", got, tt.wantLog, logs.String())
+			}
+		})
+	}
+}
+
+func TestBuildTags(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"main.go":         "package main\n",
+		"integration.go":  "//go:build integration\n\npackage main\n",
+		"notwindows.go":   "//go:build !windows\n\npackage main\n",
+		"legacy.go":       "// +build legacy\n\npackage main\n",
+		"x_windows.go":    "package main\n",
+		"integration2.go": "//go:build integration && !short\n\npackage main\n",
+	})
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"default", nil, []string{"main.go", "notwindows.go"}},
+		{"integration", []string{"integration"}, []string{"integration.go", "integration2.go", "main.go", "notwindows.go"}},
+		{"integration short", []string{"integration", "short"}, []string{"integration.go", "main.go", "notwindows.go"}},
+		{"legacy", []string{"legacy"}, []string{"legacy.go", "main.go", "notwindows.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.Tags = tt.tags
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgress(t *testing.T) {
+	root := manyFilesProject(t, 3)
+	tests := []struct {
+		name     string
+		progress bool
+		want     []string
+	}{
+		{"disabled", false, []string{"Parsed 3 files: 3 functions, 0 test functions, 0 errors"}},
+		{"enabled", true, []string{"parsed 1/3 files", "parsed 2/3 files", "parsed 3/3 files", "Parsed 3 files: 3 functions, 0 test functions, 0 errors"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.Progress = tt.progress
+			var err error
+			var stdout string
+			stderr := capture(t, &os.Stderr, func() {
+				stdout = capture(t, &os.Stdout, func() {
+					err = p.Process(context.Background())
+				})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stdout != "" {
+				t.Errorf("stdout = %q, want nothing", stdout)
+			}
+			if got := strings.Split(strings.TrimSuffix(stderr, "\n"), "\n"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stderr lines = %q, want %q", got, tt.want)
+			}
+			for name, content := range readOutputs(t, p.OutputPath) {
+				if strings.Contains(strings.ToLower(content), "parsed ") {
+					t.Errorf("%s contains progress output", name)
+				}
+			}
+		})
+	}
+}
+
+func TestQuiet(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"good.go":   "package p\n\nfunc Good() {}\n",
+		"broken.go": "package p\n\nfunc Broken( {\n",
+	})
+	tests := []struct {
+		name    string
+		args    []string
+		wantLog bool
+	}{
+		{"default", nil, true},
+		{"quiet", []string{"--quiet"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs strings.Builder
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+
+			args := append([]string{"parse", "--project", root, "--output", t.TempDir()}, tt.args...)
+			err := createCliApp().Run(args)
+			if err == nil || !strings.Contains(err.Error(), "failed to parse 1 of 2 files") {
+				t.Errorf("err = %v, want the failed file counted", err)
+			}
+			if got := strings.Contains(logs.String(), "broken.go"); got != tt.wantLog {
+				t.Errorf("logged the parse error: %t, want %t\n%s", got, tt.wantLog, logs.String())
+			}
+			if !tt.wantLog && logs.Len() != 0 {
+				t.Errorf("quiet run logged:\n%s", logs.String())
+			}
+		})
+	}
+}
+
+func TestSkipErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		setup func(t *testing.T, root string)
+	}{
+		{
+			name: "bad build constraint",
+			files: map[string]string{
+				"good/good.go": "package good\n\nfunc Good() {}\n",
+				"bad/bad.go":   "//go:build (linux\n\npackage bad\n",
+			},
+		},
+		{
+			name: "unreadable directory",
+			files: map[string]string{
+				"good/good.go":   "package good\n\nfunc Good() {}\n",
+				"locked/hide.go": "package locked\n",
+			},
+			setup: func(t *testing.T, root string) {
+				if os.Geteuid() == 0 {
+					t.Skip("permissions are not enforced for root")
+				}
+				locked := filepath.Join(root, "locked")
+				if err := os.Chmod(locked, 0); err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { os.Chmod(locked, 0755) })
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProject(t, tt.files)
+			if tt.setup != nil {
+				tt.setup(t, root)
+			}
+
+			p := newTestProcessor(t, root)
+			if _, err := p.findGoFiles(); err == nil {
+				t.Error("walk succeeded without --skip-errors")
+			}
+
+			p.SkipErrors = true
+			var logs strings.Builder
+			p.Logger = log.New(&logs, "", 0)
+			if got, want := relGoFiles(t, p, root), []string{"good/good.go"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("files = %q, want %q", got, want)
+			}
+			if !strings.Contains(logs.String(), "Skipping ") {
+				t.Errorf("skipped path was not logged:\n%s", logs.String())
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":      "package a\n\nfunc A() {}\n\nfunc B() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	})
+	summary := regexp.MustCompile(`^dry run: 2 files, 2 functions, 1 test functions, ([1-9][0-9]*) bytes of output\n$`)
+	for _, format := range []string{"", formatJSON, formatCombinedJSON} {
+		t.Run("format="+format, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.OutputPath = filepath.Join(t.TempDir(), "out")
+			p.Format = format
+			p.DryRun = true
+			var err error
+			stderr := capture(t, &os.Stderr, func() {
+				err = p.Process(context.Background())
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := summary.FindStringSubmatch(stderr)
+			if m == nil {
+				t.Fatalf("stderr = %q, want the dry run summary", stderr)
+			}
+			if _, err := os.Stat(p.OutputPath); !os.IsNotExist(err) {
+				t.Errorf("output directory was created: %v", err)
+			}
+
+			p = newTestProcessor(t, root)
+			p.Format = format
+			if err := p.Process(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			entries, err := os.ReadDir(p.OutputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var written int64
+			for _, entry := range entries {
+				info, err := entry.Info()
+				if err != nil {
+					t.Fatal(err)
+				}
+				written += info.Size()
+			}
+			if m[1] != fmt.Sprint(written) {
+				t.Errorf("dry run reported %s bytes, a real run wrote %d", m[1], written)
+			}
+		})
+	}
+}
+
+func TestMultipleRoots(t *testing.T) {
+	api := writeProject(t, map[string]string{
+		"main.go":        "// Command api serves.\npackage main\n\nfunc Serve() {}\n",
+		"store/store.go": "package store\n\nfunc Get() {}\n",
+	})
+	worker := writeProject(t, map[string]string{
+		"main.go": "// Command worker works.\npackage main\n\nfunc Work() {}\n",
+	})
+
+	p := newTestProcessor(t, api, worker, filepath.Join(api, "store"))
+	if err := p.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	type location struct{ Name, Root, FilePath string }
+	var functions []location
+	for _, desc := range readJSONOutput(t, p.OutputPath, defaultFunctionsFile).Functions {
+		functions = append(functions, location{desc.Name, desc.Root, desc.FilePath})
+	}
+	wantFunctions := []location{
+		{"Serve", filepath.ToSlash(api), "main.go"},
+		{"Work", filepath.ToSlash(worker), "main.go"},
+		{"Get", filepath.ToSlash(api), "store/store.go"},
+	}
+	if !reflect.DeepEqual(functions, wantFunctions) {
+		t.Errorf("functions = %+v\nwant %+v", functions, wantFunctions)
+	}
+
+	packages := readJSONOutput(t, p.OutputPath, "packages.json").Packages
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Doc < packages[j].Doc })
+	wantPackages := []PackageDescription{
+		{Name: "store", Root: filepath.ToSlash(api), Dir: "store", Files: []string{"store/store.go"}},
+		{Name: "main", Root: filepath.ToSlash(api), Dir: ".", Doc: "Command api serves.", Files: []string{"main.go"}},
+		{Name: "main", Root: filepath.ToSlash(worker), Dir: ".", Doc: "Command worker works.", Files: []string{"main.go"}},
+	}
+	if !reflect.DeepEqual(packages, wantPackages) {
+		t.Errorf("packages = %+v\nwant %+v", packages, wantPackages)
+	}
+}
+
+func TestAbsoluteAndRelativeProject(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"main.go":         "package main\n\nfunc main() {}\n",
+		"cmd/cmd.go":      "package cmd\n\nfunc Run() {}\n",
+		"cmd/a/a.go":      "package a\n\nfunc A() {}\n",
+		"cmd/a/a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	})
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Dir(root)); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	rel := filepath.Base(root)
+	var paths [][]string
+	for _, project := range []string{root, rel, "./" + rel, rel + "/", filepath.Join(rel, "cmd", "..")} {
+		p := newTestProcessor(t, project)
+		if err := p.Process(context.Background()); err != nil {
+			t.Fatalf("%s: %v", project, err)
+		}
+		var got []string
+		for _, name := range []string{defaultFunctionsFile, defaultTestsFile} {
+			output := readJSONOutput(t, p.OutputPath, name)
+			for _, desc := range append(output.Functions, output.TestFunctions...) {
+				got = append(got, desc.FilePath)
+			}
+		}
+		for _, file := range readJSONOutput(t, p.OutputPath, "files.json").Files {
+			got = append(got, file.FilePath)
+		}
+		paths = append(paths, got)
+	}
+	want := []string{"cmd/a/a.go", "cmd/cmd.go", "main.go", "cmd/a/a_test.go", "cmd/a/a.go", "cmd/a/a_test.go", "cmd/cmd.go", "main.go"}
+	for i, got := range paths {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("run %d: paths = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	files := map[string]string{
+		"a.go":      "package a\n\nfunc A() {}\n\nfunc B() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	}
+	tests := []struct {
+		name    string
+		broken  bool
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", want: "Parsed 2 files: 2 functions, 1 test functions, 0 errors\n"},
+		{name: "quiet", args: []string{"--quiet"}, want: "Parsed 2 files: 2 functions, 1 test functions, 0 errors\n"},
+		{name: "errors", broken: true, args: []string{"--quiet"}, want: "Parsed 2 files: 2 functions, 1 test functions, 1 errors\n", wantErr: true},
+		{name: "dry run", args: []string{"--dry-run"}, want: "dry run: 2 files, 2 functions, 1 test functions, "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProject(t, files)
+			if tt.broken {
+				if err := os.WriteFile(filepath.Join(root, "broken.go"), []byte("package a\n\nfunc ("), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var logs strings.Builder
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+
+			var err error
+			stderr := capture(t, &os.Stderr, func() {
+				err = createCliApp().Run(append([]string{"parse", "--project", root, "--output", t.TempDir()}, tt.args...))
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error: %t", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(stderr, tt.want) || strings.Count(stderr, "\n") != 1 {
+				t.Errorf("stderr = %q, want the summary %q", stderr, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoTests(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":          "package p\n\nfunc A() {}\n",
+		"a_test.go":     "package p\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+		"sub/b_test.go": "package sub\n\nimport \"testing\"\n\nfunc TestB(t *testing.T) {}\n",
+		"contest.go":    "package p\n\nfunc Contest() {}\n",
+	})
+	tests := []struct {
+		name    string
+		project string
+		noTests bool
+		want    []string
+	}{
+		{"directory", "", false, []string{"a.go", "a_test.go", "contest.go", "sub/b_test.go"}},
+		{"directory without tests", "", true, []string{"a.go", "contest.go"}},
+		{"test file", "a_test.go", false, []string{"a_test.go"}},
+		{"test file without tests", "a_test.go", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, filepath.Join(root, tt.project))
+			p.NoTests = tt.noTests
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingleFileFilter(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":         "package p\n\nfunc A() {}\n",
+		"a_test.go":    "package p\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+		"tagged.go":    "//go:build integration\n\npackage p\n",
+		"generator.go": "//go:build ignore\n\npackage main\n",
+		"big.go":       "package p\n\n" + strings.Repeat("// padding\n", 100),
+	})
+	tests := []struct {
+		file      string
+		configure func(p *ProjectProcessor)
+		want      bool
+	}{
+		{"a.go", func(p *ProjectProcessor) {}, true},
+		{"a.go", func(p *ProjectProcessor) { p.TestsOnly = true }, false},
+		{"a_test.go", func(p *ProjectProcessor) { p.TestsOnly = true }, true},
+		{"a_test.go", func(p *ProjectProcessor) { p.NoTests = true }, false},
+		{"tagged.go", func(p *ProjectProcessor) {}, false},
+		{"tagged.go", func(p *ProjectProcessor) { p.Tags = []string{"integration"} }, true},
+		{"generator.go", func(p *ProjectProcessor) {}, false},
+		{"generator.go", func(p *ProjectProcessor) { p.IncludeIgnored = true }, true},
+		{"big.go", func(p *ProjectProcessor) { p.MaxFileSize = 100 }, false},
+		{"big.go", func(p *ProjectProcessor) { p.MaxFileSize = 10000 }, true},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.file), func(t *testing.T) {
+			p := newTestProcessor(t, filepath.Join(root, tt.file))
+			tt.configure(p)
+			var want []string
+			if tt.want {
+				want = []string{tt.file}
+			}
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, want) {
+				t.Errorf("files = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestInvalidFuncPatterns(t *testing.T) {
+	root := writeProject(t, map[string]string{"a.go": "package a\n"})
+	tests := []struct {
+		name      string
+		configure func(p *ProjectProcessor)
+		want      string
+	}{
+		{"exclude", func(p *ProjectProcessor) { p.ExcludeFuncs = "(" }, "invalid --exclude-funcs pattern"},
+		{"include", func(p *ProjectProcessor) { p.IncludeFuncs = "[" }, "invalid --include-funcs pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			tt.configure(p)
+			if err := p.Process(context.Background()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreTag(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"main.go":        "package main\n",
+		"gen.go":         "//go:build ignore\n\npackage main\n",
+		"legacygen.go":   "// +build ignore\n\npackage main\n",
+		"gentagged.go":   "//go:build ignore && integration\n\npackage main\n",
+		"integration.go": "//go:build integration\n\npackage main\n",
+	})
+	tests := []struct {
+		name           string
+		includeIgnored bool
+		want           []string
+	}{
+		{"default", false, []string{"main.go"}},
+		{"include ignored", true, []string{"gen.go", "legacygen.go", "main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.IncludeIgnored = tt.includeIgnored
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
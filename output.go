@@ -0,0 +1,684 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"parse/pb"
+)
+
+const (
+	formatText         = "text"
+	formatJSON         = "json"
+	formatTestJSON     = "test-json"
+	formatCombinedJSON = "combined-json"
+	formatYAML         = "yaml"
+	formatMarkdown     = "markdown-table"
+	formatDot          = "dot"
+	formatProtobuf     = "protobuf"
+	formatNDJSON       = "ndjson"
+	formatHTML         = "html"
+	formatSignatures   = "signatures"
+	formatDocs         = "docs"
+)
+
+const (
+	defaultFunctionsFile    = "functions.json"
+	defaultTestsFile        = "test_functions.json"
+	defaultDescriptionsFile = "all_function_descriptions.txt"
+)
+
+// schemaVersion is reported in every JSON output. Bump it whenever the
+// serialized shape of the descriptions changes.
+const schemaVersion = 26
+
+// JSONOutput is the document written by combined-json. The functions and
+// test_functions keys are always present; single-purpose files are written by
+// writeJSONFile with the same schema_version and generated_at header.
+type JSONOutput struct {
+	SchemaVersion    int                           `json:"schema_version"`
+	GeneratedAt      string                        `json:"generated_at"`
+	Functions        []FunctionDescription         `json:"functions"`
+	TestFunctions    []FunctionDescription         `json:"test_functions"`
+	Files            []FileDescription             `json:"files,omitempty"`
+	Types            []TypeDescription             `json:"types,omitempty"`
+	Decls            []DeclDescription             `json:"decls,omitempty"`
+	Packages         []PackageDescription          `json:"packages,omitempty"`
+	Duplicates       []DuplicateDescription        `json:"duplicates,omitempty"`
+	Todos            []TodoDescription             `json:"todos,omitempty"`
+	Index            map[string][]FunctionLocation `json:"index,omitempty"`
+	IgnoredErrors    []IgnoredErrorDescription     `json:"ignored_errors,omitempty"`
+	PackageStats     []PackageStats                `json:"package_stats,omitempty"`
+	FullDescriptions []string                      `json:"full_descriptions,omitempty"`
+}
+
+var formatWriters = map[string]func(*ProjectProcessor, Func) error{
+	formatText:         (*ProjectProcessor).writeDescriptions,
+	formatJSON:         (*ProjectProcessor).writeFunctions,
+	formatTestJSON:     (*ProjectProcessor).writeTestFunctions,
+	formatCombinedJSON: (*ProjectProcessor).writeCombined,
+	formatYAML:         (*ProjectProcessor).writeYAML,
+	formatMarkdown:     (*ProjectProcessor).writeMarkdownTable,
+	formatDot:          (*ProjectProcessor).writeCallGraph,
+	formatProtobuf:     (*ProjectProcessor).writeProtobuf,
+	formatNDJSON:       (*ProjectProcessor).writeNDJSON,
+	formatHTML:         (*ProjectProcessor).writeHTML,
+	formatSignatures:   (*ProjectProcessor).writeSignatures,
+	formatDocs:         (*ProjectProcessor).writeDocs,
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formatWriters))
+	for name := range formatWriters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if _, ok := formatWriters[format]; !ok {
+		return fmt.Errorf("unknown output format %q, valid formats are: %s", format, strings.Join(formatNames(), ", "))
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) validateFileNames() error {
+	for _, f := range []struct{ flag, name string }{
+		{"functions-file", p.FunctionsFile},
+		{"tests-file", p.TestsFile},
+		{"descriptions-file", p.DescriptionsFile},
+	} {
+		if f.name != "" && (f.name != filepath.Base(f.name) || f.name == "." || f.name == "..") {
+			return fmt.Errorf("--%s must be a file name, not a path: %q", f.flag, f.name)
+		}
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) functionsFile() string {
+	return fileNameOrDefault(p.FunctionsFile, defaultFunctionsFile)
+}
+
+func (p *ProjectProcessor) testsFile() string {
+	return fileNameOrDefault(p.TestsFile, defaultTestsFile)
+}
+
+func (p *ProjectProcessor) descriptionsFile() string {
+	return fileNameOrDefault(p.DescriptionsFile, defaultDescriptionsFile)
+}
+
+func fileNameOrDefault(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// suffixedFileName inserts suffix between the base name and the extension.
+func suffixedFileName(name, suffix string) string {
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "_" + suffix + ext
+}
+
+func (p *ProjectProcessor) writeOutputFiles(funcDescriptions Func) error {
+	p.generatedAt = time.Now().UTC()
+	write := (*ProjectProcessor).writeAllOutputFiles
+	if p.Format != "" {
+		var ok bool
+		if write, ok = formatWriters[p.Format]; !ok {
+			return validateFormat(p.Format)
+		}
+	}
+	if err := write(p, funcDescriptions); err != nil {
+		return err
+	}
+	if p.DryRun {
+		p.writeDryRunSummary(os.Stderr, funcDescriptions)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeDryRunSummary(w io.Writer, funcDescriptions Func) {
+	fmt.Fprintf(w, "dry run: %d files, %d functions, %d test functions, %d bytes of output\n",
+		len(funcDescriptions.FileDescriptions),
+		len(funcDescriptions.FunctionDescriptions),
+		len(funcDescriptions.TestFunctionDescriptions),
+		p.dryRunBytes)
+}
+
+func (p *ProjectProcessor) writeAllOutputFiles(funcDescriptions Func) error {
+	if err := p.writeDescriptions(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeTestFunctions(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeFiles(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeTypes(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeDecls(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writePackages(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeDuplicates(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeTodos(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeIndex(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writeIgnoredErrors(funcDescriptions); err != nil {
+		return err
+	}
+	if err := p.writePackageStats(funcDescriptions); err != nil {
+		return err
+	}
+	return p.writeFunctions(funcDescriptions)
+}
+
+func (p *ProjectProcessor) writeDescriptions(funcDescriptions Func) error {
+	if p.MaxChars > 0 {
+		for i, chunk := range chunkDescriptions(funcDescriptions, p.MaxChars) {
+			filename := suffixedFileName(p.descriptionsFile(), fmt.Sprintf("%03d", i+1))
+			if err := p.writeToFile(chunk, filename); err != nil {
+				return fmt.Errorf("failed to write descriptions to file: %w", err)
+			}
+		}
+		return nil
+	}
+
+	allDescriptions := combineDescriptions(funcDescriptions)
+	if err := p.writeToFile(allDescriptions, p.descriptionsFile()); err != nil {
+		return fmt.Errorf("failed to write descriptions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeTestFunctions(funcDescriptions Func) error {
+	if err := p.writeJSONFile(p.testsFile(), "test_functions", funcDescriptions.TestFunctionDescriptions); err != nil {
+		return fmt.Errorf("failed to write test functions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeFunctions(funcDescriptions Func) error {
+	if p.SplitByPackage {
+		return p.writeFunctionsByPackage(funcDescriptions)
+	}
+	if err := p.writeJSONFile(p.functionsFile(), "functions", funcDescriptions.FunctionDescriptions); err != nil {
+		return fmt.Errorf("failed to write functions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeFunctionsByPackage(funcDescriptions Func) error {
+	byPackage := make(map[string][]FunctionDescription)
+	for _, desc := range funcDescriptions.FunctionDescriptions {
+		byPackage[desc.Package] = append(byPackage[desc.Package], desc)
+	}
+
+	packages := make([]string, 0, len(byPackage))
+	for pkg := range byPackage {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	for _, pkg := range packages {
+		filename := suffixedFileName(p.functionsFile(), sanitizeFileName(pkg))
+		if err := p.writeJSONFile(filename, "functions", byPackage[pkg]); err != nil {
+			return fmt.Errorf("failed to write functions of package %s to file: %w", pkg, err)
+		}
+	}
+	return nil
+}
+
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (p *ProjectProcessor) writeFiles(funcDescriptions Func) error {
+	if err := p.writeJSONFile("files.json", "files", funcDescriptions.FileDescriptions); err != nil {
+		return fmt.Errorf("failed to write file descriptions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeTypes(funcDescriptions Func) error {
+	if err := p.writeJSONFile("types.json", "types", funcDescriptions.TypeDescriptions); err != nil {
+		return fmt.Errorf("failed to write types to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeDecls(funcDescriptions Func) error {
+	if err := p.writeJSONFile("decls.json", "decls", funcDescriptions.DeclDescriptions); err != nil {
+		return fmt.Errorf("failed to write constants and variables to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writePackages(funcDescriptions Func) error {
+	if err := p.writeJSONFile("packages.json", "packages", funcDescriptions.PackageDescriptions); err != nil {
+		return fmt.Errorf("failed to write package descriptions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeDuplicates(funcDescriptions Func) error {
+	duplicates := findDuplicates(funcDescriptions.FunctionDescriptions)
+	if err := p.writeJSONFile("duplicates.json", "duplicates", duplicates); err != nil {
+		return fmt.Errorf("failed to write duplicate functions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeTodos(funcDescriptions Func) error {
+	if err := p.writeJSONFile("todos.json", "todos", funcDescriptions.TodoDescriptions); err != nil {
+		return fmt.Errorf("failed to write todos to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeIndex(funcDescriptions Func) error {
+	if err := p.writeJSONFile("index.json", "index", buildIndex(funcDescriptions)); err != nil {
+		return fmt.Errorf("failed to write function index to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeIgnoredErrors(funcDescriptions Func) error {
+	if err := p.writeJSONFile("ignored_errors.json", "ignored_errors", funcDescriptions.IgnoredErrorDescriptions); err != nil {
+		return fmt.Errorf("failed to write ignored errors to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writePackageStats(funcDescriptions Func) error {
+	if err := p.writeJSONFile("package_stats.json", "package_stats", buildPackageStats(funcDescriptions)); err != nil {
+		return fmt.Errorf("failed to write package statistics to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeCombined(funcDescriptions Func) error {
+	combined := JSONOutput{
+		Functions:        nonNilSlice(funcDescriptions.FunctionDescriptions).([]FunctionDescription),
+		TestFunctions:    nonNilSlice(funcDescriptions.TestFunctionDescriptions).([]FunctionDescription),
+		Files:            funcDescriptions.FileDescriptions,
+		Types:            funcDescriptions.TypeDescriptions,
+		Decls:            funcDescriptions.DeclDescriptions,
+		Packages:         funcDescriptions.PackageDescriptions,
+		Duplicates:       findDuplicates(funcDescriptions.FunctionDescriptions),
+		Todos:            funcDescriptions.TodoDescriptions,
+		Index:            buildIndex(funcDescriptions),
+		IgnoredErrors:    funcDescriptions.IgnoredErrorDescriptions,
+		PackageStats:     buildPackageStats(funcDescriptions),
+		FullDescriptions: funcDescriptions.FullDescriptions,
+	}
+	combined.SchemaVersion = schemaVersion
+	combined.GeneratedAt = p.generatedAt.Format(time.RFC3339)
+	b, err := json.Marshal(combined)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	if err := p.writeToFile(string(b), "combined.json"); err != nil {
+		return fmt.Errorf("failed to write combined output to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeYAML(funcDescriptions Func) error {
+	if err := p.writeYAMLFile(funcDescriptions.FunctionDescriptions, "functions.yaml"); err != nil {
+		return fmt.Errorf("failed to write functions to file: %w", err)
+	}
+	if err := p.writeYAMLFile(funcDescriptions.TestFunctionDescriptions, "test_functions.yaml"); err != nil {
+		return fmt.Errorf("failed to write test functions to file: %w", err)
+	}
+	return nil
+}
+
+//go:generate protoc --go_out=. --go_opt=module=parse functions.proto
+
+func (p *ProjectProcessor) writeProtobuf(funcDescriptions Func) error {
+	msg := &pb.FunctionDescriptions{
+		SchemaVersion: schemaVersion,
+		GeneratedAt:   p.generatedAt.Format(time.RFC3339),
+		Functions:     protoFunctionDescriptions(funcDescriptions.FunctionDescriptions),
+		TestFunctions: protoFunctionDescriptions(funcDescriptions.TestFunctionDescriptions),
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	if err := p.writeToFile(string(b), "functions.pb"); err != nil {
+		return fmt.Errorf("failed to write protobuf output to file: %w", err)
+	}
+	return nil
+}
+
+func protoFunctionDescriptions(descs []FunctionDescription) []*pb.FunctionDescription {
+	msgs := make([]*pb.FunctionDescription, 0, len(descs))
+	for _, desc := range descs {
+		msgs = append(msgs, protoFunctionDescription(desc))
+	}
+	return msgs
+}
+
+func protoFunctionDescription(desc FunctionDescription) *pb.FunctionDescription {
+	msg := &pb.FunctionDescription{
+		Name:            desc.Name,
+		Doc:             desc.Doc,
+		Signature:       desc.Signature,
+		Package:         desc.Package,
+		FilePath:        desc.FilePath,
+		IsTestFunction:  desc.IsTestFunction,
+		Kind:            desc.Kind,
+		Receiver:        desc.Receiver,
+		IsMethod:        desc.IsMethod,
+		StartLine:       int64(desc.StartLine),
+		EndLine:         int64(desc.EndLine),
+		StartCol:        int64(desc.StartCol),
+		Calls:           desc.Calls,
+		Complexity:      int64(desc.Complexity),
+		LineCount:       int64(desc.LineCount),
+		Deprecated:      desc.Deprecated,
+		DeprecationNote: desc.DeprecationNote,
+		HasDoc:          desc.HasDoc,
+		ReferencedTypes: desc.ReferencedTypes,
+		Exported:        desc.Exported,
+		Warnings:        desc.Warnings,
+		Root:            desc.Root,
+		ReturnsError:    desc.ReturnsError,
+		AcceptsContext:  desc.AcceptsContext,
+		GoStatements:    int64(desc.GoStatements),
+		Defers:          int64(desc.Defers),
+		CallsPanic:      desc.CallsPanic,
+		Parameters:      protoParameters(desc.Parameters),
+		Results:         protoParameters(desc.Results),
+		ParamCount:      int64(desc.ParamCount),
+		ResultCount:     int64(desc.ResultCount),
+		ReceiverType:    desc.ReceiverType,
+	}
+	for _, closure := range desc.Closures {
+		msg.Closures = append(msg.Closures, &pb.ClosureDescription{
+			Signature: closure.Signature,
+			StartLine: int64(closure.StartLine),
+			EndLine:   int64(closure.EndLine),
+			StartCol:  int64(closure.StartCol),
+		})
+	}
+	for _, typeParam := range desc.TypeParams {
+		msg.TypeParams = append(msg.TypeParams, &pb.TypeParam{Name: typeParam.Name, Constraint: typeParam.Constraint})
+	}
+	return msg
+}
+
+func protoParameters(params []ParameterDescription) []*pb.ParameterDescription {
+	var msgs []*pb.ParameterDescription
+	for _, param := range params {
+		msgs = append(msgs, &pb.ParameterDescription{Name: param.Name, Type: param.Type, External: param.External})
+	}
+	return msgs
+}
+
+// writeNDJSON writes one JSON object per line for every function, followed by
+// the test functions, so the output can be streamed.
+func (p *ProjectProcessor) writeNDJSON(funcDescriptions Func) error {
+	var sb strings.Builder
+	encoder := json.NewEncoder(&sb)
+	for _, descriptions := range [][]FunctionDescription{funcDescriptions.FunctionDescriptions, funcDescriptions.TestFunctionDescriptions} {
+		for _, desc := range descriptions {
+			if err := encoder.Encode(desc); err != nil {
+				return fmt.Errorf("failed to marshal function %s: %w", desc.Name, err)
+			}
+		}
+	}
+	if err := p.writeToFile(sb.String(), "functions.ndjson"); err != nil {
+		return fmt.Errorf("failed to write functions to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeHTML(funcDescriptions Func) error {
+	report, err := renderHTMLReport(funcDescriptions)
+	if err != nil {
+		return fmt.Errorf("failed to render html report: %w", err)
+	}
+	if err := p.writeToFile(report, "report.html"); err != nil {
+		return fmt.Errorf("failed to write html report to file: %w", err)
+	}
+	return nil
+}
+
+// DocDescription is the lean projection of a function written by the docs
+// format: its name, package and doc comment only.
+type DocDescription struct {
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	Doc     string `json:"doc"`
+}
+
+// writeDocs writes docs.json as a plain array, without the schema_version and
+// generated_at envelope of the other JSON files, so doc sites can consume it
+// as is.
+func (p *ProjectProcessor) writeDocs(funcDescriptions Func) error {
+	b, err := json.Marshal(nonNilSlice(funcDescriptions.DocDescriptions))
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	if err := p.writeToFile(string(b), "docs.json"); err != nil {
+		return fmt.Errorf("failed to write docs to file: %w", err)
+	}
+	return nil
+}
+
+// writeSignatures writes one package qualified signature per line, such as
+// "pkg.Parse(s string) error" or "pkg.(t *T) Close() error" for methods.
+// Test functions are left out.
+func (p *ProjectProcessor) writeSignatures(funcDescriptions Func) error {
+	var sb strings.Builder
+	for _, desc := range funcDescriptions.FunctionDescriptions {
+		sb.WriteString(desc.Package + "." + strings.TrimPrefix(desc.Signature, "func ") + "\n")
+	}
+	if err := p.writeToFile(sb.String(), "signatures.txt"); err != nil {
+		return fmt.Errorf("failed to write signatures to file: %w", err)
+	}
+	return nil
+}
+
+func (p *ProjectProcessor) writeMarkdownTable(funcDescriptions Func) error {
+	var sb strings.Builder
+	sb.WriteString("| Package | Function | Kind | Line | Complexity |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, descriptions := range [][]FunctionDescription{funcDescriptions.FunctionDescriptions, funcDescriptions.TestFunctionDescriptions} {
+		for _, desc := range descriptions {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d |\n", desc.Package, desc.Name, desc.Kind, desc.StartLine, desc.Complexity))
+		}
+	}
+	if err := p.writeToFile(sb.String(), "summary.md"); err != nil {
+		return fmt.Errorf("failed to write summary to file: %w", err)
+	}
+	return nil
+}
+
+const descriptionsHeader = "#### This is detailed description of all functions in the project its references\n"
+
+func (p *ProjectProcessor) writeCallGraph(funcDescriptions Func) error {
+	if err := p.writeToFile(buildCallGraph(funcDescriptions), "calls.dot"); err != nil {
+		return fmt.Errorf("failed to write call graph to file: %w", err)
+	}
+	return nil
+}
+
+func combineDescriptions(funcDescriptions Func) string {
+	var allDescriptions strings.Builder
+	allDescriptions.WriteString(descriptionsHeader)
+	for _, desc := range funcDescriptions.FullDescriptions {
+		allDescriptions.WriteString(desc)
+	}
+	return allDescriptions.String()
+}
+
+// chunkDescriptions packs the description text into chunks of at most
+// maxChars characters, each starting with the usual header. Chunks only break
+// between functions, so a single function longer than maxChars gets a chunk
+// of its own.
+func chunkDescriptions(funcDescriptions Func, maxChars int) []string {
+	var units []string
+	for _, desc := range funcDescriptions.FullDescriptions {
+		parts := strings.SplitAfter(desc, functionEndSuffix)
+		// The file footer follows the last function and belongs with it.
+		if n := len(parts); n > 1 {
+			parts[n-2] += parts[n-1]
+			parts = parts[:n-1]
+		}
+		units = append(units, parts...)
+	}
+
+	var chunks []string
+	var current strings.Builder
+	for _, unit := range units {
+		if current.Len() > len(descriptionsHeader) && current.Len()+len(unit) > maxChars {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() == 0 {
+			current.WriteString(descriptionsHeader)
+		}
+		current.WriteString(unit)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+func (p *ProjectProcessor) writeToFile(content, filename string) error {
+	if p.DryRun {
+		p.dryRunBytes += len(content)
+		return nil
+	}
+	if p.Stdout {
+		if _, err := os.Stdout.WriteString(content); err != nil {
+			return fmt.Errorf("failed to write to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if p.Gzip {
+		filename += ".gz"
+	}
+	fullPath := filepath.Join(p.OutputPath, filename)
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			p.logger().Printf("failed to close file: %v", err)
+		}
+	}(file)
+
+	if !p.Gzip {
+		if _, err := file.WriteString(content); err != nil {
+			return fmt.Errorf("failed to write to file: %w", err)
+		}
+		return nil
+	}
+
+	zw := gzip.NewWriter(file)
+	if _, err := io.WriteString(zw, content); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+	return nil
+}
+
+// writeJSONFile writes payload under key, after the schema_version and
+// generated_at header. The key is always present: an empty slice is written
+// as [] rather than omitted or null.
+func (p *ProjectProcessor) writeJSONFile(filename, key string, payload interface{}) error {
+	fields := []struct {
+		key   string
+		value interface{}
+	}{
+		{"schema_version", schemaVersion},
+		{"generated_at", p.generatedAt.Format(time.RFC3339)},
+		{key, nonNilSlice(payload)},
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(field.key)
+		if err != nil {
+			return fmt.Errorf("failed to marshal data: %w", err)
+		}
+		v, err := json.Marshal(field.value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal data: %w", err)
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return p.writeToFile(buf.String(), filename)
+}
+
+// nonNilSlice returns an empty slice of the same type for a nil slice, so it
+// is marshalled as [] instead of null. Any other value is returned unchanged.
+func nonNilSlice(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+	return v
+}
+
+func (p *ProjectProcessor) writeYAMLFile(data interface{}, filename string) error {
+	b, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	content := string(b)
+	if p.Stdout {
+		// Both YAML outputs share stdout, so each is written as its own document.
+		content = "---\n" + content
+	}
+	return p.writeToFile(content, filename)
+}
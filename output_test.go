@@ -0,0 +1,776 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readJSONOutput decodes the JSON file name in dir.
+func readJSONOutput(t *testing.T, dir, name string) JSONOutput {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output JSONOutput
+	if err := json.Unmarshal(b, &output); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return output
+}
+
+// processProject writes files to a temporary project, runs the processor on
+// it after applying configure, and returns the output directory.
+func processProject(t *testing.T, files map[string]string, configure func(*ProjectProcessor)) string {
+	t.Helper()
+	p := newTestProcessor(t, writeProject(t, files))
+	if configure != nil {
+		configure(p)
+	}
+	if err := p.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return p.OutputPath
+}
+
+func TestFilePathInJSON(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a/parse.go":      "package a\n\nfunc Parse() {}\n",
+		"b/parse.go":      "package b\n\nfunc Parse() {}\n",
+		"b/parse_test.go": "package b\n\nimport \"testing\"\n\nfunc TestParse(t *testing.T) {}\n",
+	}, nil)
+
+	functions := readJSONOutput(t, out, defaultFunctionsFile).Functions
+	var paths []string
+	for _, desc := range functions {
+		paths = append(paths, desc.FilePath)
+	}
+	if want := []string{"a/parse.go", "b/parse.go"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("function file paths = %q, want %q", paths, want)
+	}
+	tests := readJSONOutput(t, out, defaultTestsFile).TestFunctions
+	if len(tests) != 1 || tests[0].FilePath != "b/parse_test.go" {
+		t.Errorf("test functions = %+v, want TestParse in b/parse_test.go", tests)
+	}
+}
+
+func TestJSONEnvelope(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		configure func(*ProjectProcessor)
+		file      string
+		key       string
+		wantLen   int
+	}{
+		{"functions", map[string]string{"a.go": "package a\n\nfunc A() {}\n"}, nil, defaultFunctionsFile, "functions", 1},
+		{"no test functions", map[string]string{"a.go": "package a\n\nfunc A() {}\n"}, nil, defaultTestsFile, "test_functions", 0},
+		{"tests only", map[string]string{
+			"a.go":      "package a\n\nfunc A() {}\n",
+			"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+		}, func(p *ProjectProcessor) { p.TestsOnly = true }, defaultFunctionsFile, "functions", 0},
+		{"allow empty", map[string]string{"README": "nothing to parse\n"}, func(p *ProjectProcessor) { p.AllowEmpty = true }, defaultFunctionsFile, "functions", 0},
+		{"empty types", map[string]string{"a.go": "package a\n"}, nil, "types.json", "types", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := processProject(t, tt.files, tt.configure)
+			b, err := os.ReadFile(filepath.Join(out, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var envelope map[string]json.RawMessage
+			if err := json.Unmarshal(b, &envelope); err != nil {
+				t.Fatal(err)
+			}
+
+			var version int
+			if err := json.Unmarshal(envelope["schema_version"], &version); err != nil || version == 0 {
+				t.Errorf("schema_version = %s, want a non-zero version", envelope["schema_version"])
+			}
+			var generatedAt string
+			if err := json.Unmarshal(envelope["generated_at"], &generatedAt); err != nil {
+				t.Errorf("generated_at = %s: %v", envelope["generated_at"], err)
+			} else if _, err := time.Parse(time.RFC3339, generatedAt); err != nil {
+				t.Errorf("generated_at is not RFC3339: %v", err)
+			}
+
+			payload, ok := envelope[tt.key]
+			if !ok {
+				t.Fatalf("%s has no %q key:\n%s", tt.file, tt.key, b)
+			}
+			var items []json.RawMessage
+			if err := json.Unmarshal(payload, &items); err != nil || items == nil {
+				t.Fatalf("%q = %s, want an array", tt.key, payload)
+			}
+			if len(items) != tt.wantLen {
+				t.Errorf("%q has %d items, want %d", tt.key, len(items), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestStdout(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":      "package a\n\nfunc A() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	})
+	tests := []struct {
+		format   string
+		wantJSON bool
+		want     string
+	}{
+		{formatJSON, true, "A"},
+		{formatTestJSON, true, "TestA"},
+		{formatText, false, "##Function name: A\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.OutputPath = ""
+			p.Stdout = true
+			p.Format = tt.format
+			var err error
+			stdout := capture(t, &os.Stdout, func() {
+				err = p.Process(context.Background())
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantJSON {
+				if !strings.Contains(stdout, tt.want) {
+					t.Errorf("stdout does not contain %q:\n%s", tt.want, stdout)
+				}
+				return
+			}
+			var output JSONOutput
+			if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+				t.Fatalf("stdout is not valid JSON: %v\n%s", err, stdout)
+			}
+			functions := append(output.Functions, output.TestFunctions...)
+			if len(functions) != 1 || functions[0].Name != tt.want {
+				t.Errorf("functions = %+v, want only %s", functions, tt.want)
+			}
+		})
+	}
+}
+
+func TestStdoutDefaultsToCombinedJSON(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":      "package a\n\nfunc A() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	})
+	var err error
+	stdout := capture(t, &os.Stdout, func() {
+		err = createCliApp().Run([]string{"parse", "--project", root, "--stdout", "--quiet"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output JSONOutput
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\n%s", err, stdout)
+	}
+	if got := functionNames(output.Functions); !reflect.DeepEqual(got, []string{"A"}) {
+		t.Errorf("functions = %q, want A", got)
+	}
+	if got := functionNames(output.TestFunctions); !reflect.DeepEqual(got, []string{"TestA"}) {
+		t.Errorf("test functions = %q, want TestA", got)
+	}
+}
+
+func TestCombinedJSON(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a.go":      "package a\n\nfunc A() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatCombinedJSON
+	})
+
+	b, err := os.ReadFile(filepath.Join(out, "combined.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(b, &keys); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"functions", "test_functions", "full_descriptions"} {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("combined.json has no %q key", key)
+		}
+	}
+
+	combined := readJSONOutput(t, out, "combined.json")
+	if len(combined.Functions) != 1 || len(combined.TestFunctions) != 1 || len(combined.FullDescriptions) != 2 {
+		t.Errorf("got %d functions, %d test functions and %d descriptions, want 1, 1 and 2",
+			len(combined.Functions), len(combined.TestFunctions), len(combined.FullDescriptions))
+	}
+	if entries, _ := os.ReadDir(out); len(entries) != 1 {
+		t.Errorf("wrote %d files, want only combined.json", len(entries))
+	}
+}
+
+func TestYAMLMatchesJSON(t *testing.T) {
+	files := map[string]string{
+		"a.go": `package a
+
+import "context"
+
+// Run does things.
+func Run[T any](ctx context.Context, items []T) (n int, err error) {
+	for range items {
+		go func() {}()
+	}
+	return 0, nil
+}
+`,
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestRun(t *testing.T) {}\n",
+	}
+	root := writeProject(t, files)
+	var outputs []string
+	for _, format := range []string{formatYAML, ""} {
+		p := newTestProcessor(t, root)
+		p.Format = format
+		if err := p.Process(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, p.OutputPath)
+	}
+	yamlOut, jsonOut := outputs[0], outputs[1]
+
+	for _, f := range []struct{ yamlFile, jsonFile string }{
+		{"functions.yaml", defaultFunctionsFile},
+		{"test_functions.yaml", defaultTestsFile},
+	} {
+		b, err := os.ReadFile(filepath.Join(yamlOut, f.yamlFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fromYAML []FunctionDescription
+		if err := yaml.Unmarshal(b, &fromYAML); err != nil {
+			t.Fatalf("%s: %v", f.yamlFile, err)
+		}
+		output := readJSONOutput(t, jsonOut, f.jsonFile)
+		fromJSON := append(output.Functions, output.TestFunctions...)
+
+		// YAML writes nil and empty lists alike, so compare the YAML encodings.
+		if got, want := mustMarshalYAML(t, fromYAML), mustMarshalYAML(t, fromJSON); got != want {
+			t.Errorf("%s does not match %s:\n%s\n%s", f.yamlFile, f.jsonFile, got, want)
+		}
+	}
+}
+
+func mustMarshalYAML(t *testing.T, v any) string {
+	t.Helper()
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestPackageFilter(t *testing.T) {
+	files := map[string]string{
+		"service/service.go": "package service\n\nfunc Serve() {}\n",
+		"store/store.go":     "package store\n\nfunc Load() {}\n",
+		"store/service.go":   "package storeservice\n\nfunc Sync() {}\n",
+	}
+	tests := []struct {
+		name     string
+		packages []string
+		want     []string
+	}{
+		{"all", nil, []string{"Serve", "Sync", "Load"}},
+		{"one", []string{"service"}, []string{"Serve"}},
+		{"two", []string{"service", "store"}, []string{"Serve", "Load"}},
+		{"unknown", []string{"svc"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := processProject(t, files, func(p *ProjectProcessor) { p.Packages = tt.packages })
+			got := functionNames(readJSONOutput(t, out, defaultFunctionsFile).Functions)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownTable(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a.go":      "package a\n\nfunc A(x int) {\n\tif x > 0 {\n\t}\n}\n\nfunc B() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	}, func(p *ProjectProcessor) { p.Format = formatMarkdown })
+
+	b, err := os.ReadFile(filepath.Join(out, "summary.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"| Package | Function | Kind | Line | Complexity |",
+		"| --- | --- | --- | --- | --- |",
+		"| a | A | regular | 3 | 2 |",
+		"| a | B | regular | 8 | 1 |",
+		"| a | TestA | test | 5 | 1 |",
+	}
+	if got := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("summary.md lines = %q, want %q", got, want)
+	}
+}
+
+func TestChunkDescriptions(t *testing.T) {
+	var src strings.Builder
+	src.WriteString("package p\n")
+	for i := 0; i < 6; i++ {
+		fmt.Fprintf(&src, "\n// F%d does thing %d.\nfunc F%d(a, b int) int { return a + b }\n", i, i, i)
+	}
+	funcs := parseTestSource(t, "p.go", src.String(), Param{})
+	funcs.Merge(parseTestSource(t, "q.go", "package p\n\nfunc Q() {}\n", Param{}))
+	whole := combineDescriptions(funcs)
+
+	for _, maxChars := range []int{1, 300, 600, len(whole)} {
+		t.Run(fmt.Sprintf("max=%d", maxChars), func(t *testing.T) {
+			chunks := chunkDescriptions(funcs, maxChars)
+			var joined strings.Builder
+			joined.WriteString(descriptionsHeader)
+			for i, chunk := range chunks {
+				body, ok := strings.CutPrefix(chunk, descriptionsHeader)
+				if !ok {
+					t.Fatalf("chunk %d does not start with the header", i)
+				}
+				joined.WriteString(body)
+
+				starts := strings.Count(chunk, "##Function name: ")
+				if ends := strings.Count(chunk, functionEndSuffix); starts != ends {
+					t.Errorf("chunk %d splits a function: %d starts, %d ends", i, starts, ends)
+				}
+				if len(chunk) > maxChars && starts > 1 {
+					t.Errorf("chunk %d has %d characters and %d functions, over the %d limit", i, len(chunk), starts, maxChars)
+				}
+			}
+			if joined.String() != whole {
+				t.Error("chunks do not add up to the whole description")
+			}
+			if maxChars == len(whole) && len(chunks) != 1 {
+				t.Errorf("got %d chunks, want 1 when everything fits", len(chunks))
+			}
+		})
+	}
+}
+
+func TestMaxCharsFiles(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a.go": "package a\n\nfunc A() {}\n",
+		"b.go": "package a\n\nfunc B() {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatText
+		p.MaxChars = 1
+	})
+	for _, name := range []string{"all_function_descriptions_001.txt", "all_function_descriptions_002.txt"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "all_function_descriptions_003.txt")); err == nil {
+		t.Error("wrote a third chunk for two functions")
+	}
+}
+
+func TestSplitByPackage(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"api/api.go":     "package api\n\nfunc Serve() {}\n",
+		"store/store.go": "package store\n\nfunc Load() {}\n\nfunc Save() {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatJSON
+		p.SplitByPackage = true
+	})
+
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"functions_api.json", "functions_store.json"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("files = %q, want %q", names, want)
+	}
+	if got := functionNames(readJSONOutput(t, out, "functions_store.json").Functions); !reflect.DeepEqual(got, []string{"Load", "Save"}) {
+		t.Errorf("store functions = %q", got)
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := map[string]string{
+		"store":      "store",
+		"my_pkg2":    "my_pkg2",
+		"../etc":     ".._etc",
+		"a b/c":      "a_b_c",
+		"v1.2-alpha": "v1.2-alpha",
+	}
+	for name, want := range tests {
+		if got := sanitizeFileName(name); got != want {
+			t.Errorf("sanitizeFileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFormats(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":      "package a\n\n// A does a.\nfunc A() { b() }\n\nfunc b() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) { A() }\n",
+	})
+	for _, format := range append(formatNames(), "") {
+		t.Run("format="+format, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.Format = format
+			if err := p.Process(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if entries, _ := os.ReadDir(p.OutputPath); len(entries) == 0 {
+				t.Error("wrote no files")
+			}
+		})
+	}
+
+	for _, format := range []string{"xml", "JSON", " json"} {
+		t.Run("format="+format, func(t *testing.T) {
+			p := newTestProcessor(t, root)
+			p.Format = format
+			err := p.Process(context.Background())
+			if err == nil {
+				t.Fatal("Process succeeded with an unknown format")
+			}
+			if msg := err.Error(); !strings.Contains(msg, fmt.Sprintf("%q", format)) || !strings.Contains(msg, strings.Join(formatNames(), ", ")) {
+				t.Errorf("error %q does not name the format and the valid choices", msg)
+			}
+			if entries, _ := os.ReadDir(p.OutputPath); len(entries) != 0 {
+				t.Errorf("wrote %d files for an unknown format", len(entries))
+			}
+		})
+	}
+}
+
+func TestCustomFileNames(t *testing.T) {
+	files := map[string]string{"a.go": "package a\n\nfunc A() {}\n"}
+	tests := []struct {
+		name      string
+		configure func(*ProjectProcessor)
+		want      []string
+		absent    []string
+		wantErr   string
+	}{
+		{
+			name:      "defaults",
+			configure: func(p *ProjectProcessor) {},
+			want:      []string{defaultDescriptionsFile, defaultFunctionsFile, defaultTestsFile},
+		},
+		{
+			name: "custom",
+			configure: func(p *ProjectProcessor) {
+				p.FunctionsFile = "funcs.json"
+				p.TestsFile = "tests.json"
+				p.DescriptionsFile = "context.txt"
+			},
+			want:   []string{"context.txt", "funcs.json", "tests.json"},
+			absent: []string{defaultDescriptionsFile, defaultFunctionsFile, defaultTestsFile},
+		},
+		{
+			name:      "path",
+			configure: func(p *ProjectProcessor) { p.FunctionsFile = "../funcs.json" },
+			wantErr:   "--functions-file must be a file name",
+		},
+		{
+			name:      "dot dot",
+			configure: func(p *ProjectProcessor) { p.TestsFile = ".." },
+			wantErr:   "--tests-file must be a file name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, writeProject(t, files))
+			tt.configure(p)
+			err := p.Process(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range tt.want {
+				if _, err := os.Stat(filepath.Join(p.OutputPath, name)); err != nil {
+					t.Error(err)
+				}
+			}
+			for _, name := range tt.absent {
+				if _, err := os.Stat(filepath.Join(p.OutputPath, name)); err == nil {
+					t.Errorf("%s was written", name)
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"a.go":      "package a\n\n// A spans\n// several lines.\nfunc A() {\n\tprintln(\"{\\n}\")\n}\n\nfunc B() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatNDJSON
+	})
+	b, err := os.ReadFile(filepath.Join(out, "functions.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(b), "}\n") {
+		t.Errorf("functions.ndjson does not end with a complete line")
+	}
+	var names []string
+	for i, line := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+		var desc FunctionDescription
+		if err := json.Unmarshal([]byte(line), &desc); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i+1, err, line)
+		}
+		names = append(names, desc.Name)
+	}
+	if want := []string{"A", "B", "TestA"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("functions = %q, want %q", names, want)
+	}
+}
+
+func TestNestedFilePaths(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"internal/store/store.go": "package store\n\n// Store stores.\ntype Store struct{}\n\nfunc (s *Store) Get() {}\n",
+	}, nil)
+	const want = "internal/store/store.go"
+	if got := readJSONOutput(t, out, defaultFunctionsFile).Functions[0].FilePath; got != want {
+		t.Errorf("function file path = %q, want %q", got, want)
+	}
+	if got := readJSONOutput(t, out, "files.json").Files[0].FilePath; got != want {
+		t.Errorf("file path = %q, want %q", got, want)
+	}
+	if got := readJSONOutput(t, out, "types.json").Types[0].FilePath; got != want {
+		t.Errorf("type file path = %q, want %q", got, want)
+	}
+}
+
+func TestTestsOnly(t *testing.T) {
+	files := map[string]string{
+		"a.go":          "package a\n\nfunc A() {}\n",
+		"a_test.go":     "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n\nfunc helper() {}\n",
+		"sub/b_test.go": "package sub\n\nimport \"testing\"\n\nfunc BenchmarkB(b *testing.B) {}\n",
+	}
+	out := processProject(t, files, func(p *ProjectProcessor) {
+		p.TestsOnly = true
+	})
+	if functions := readJSONOutput(t, out, defaultFunctionsFile).Functions; len(functions) != 0 {
+		t.Errorf("functions = %q, want none", functionNames(functions))
+	}
+	tests := functionNames(readJSONOutput(t, out, defaultTestsFile).TestFunctions)
+	if want := []string{"TestA", "helper", "BenchmarkB"}; !reflect.DeepEqual(tests, want) {
+		t.Errorf("test functions = %q, want %q", tests, want)
+	}
+	for _, file := range readJSONOutput(t, out, "files.json").Files {
+		if !strings.HasSuffix(file.FilePath, "_test.go") {
+			t.Errorf("parsed %s", file.FilePath)
+		}
+	}
+
+	p := newTestProcessor(t, writeProject(t, files))
+	p.TestsOnly = true
+	p.NoTests = true
+	if err := p.Process(context.Background()); err == nil {
+		t.Error("Process succeeded with both --tests-only and --no-tests")
+	}
+}
+
+func TestGzip(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"a.go":      "package a\n\n// A does a.\nfunc A() {}\n",
+		"a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+	})
+	plain := newTestProcessor(t, root)
+	zipped := newTestProcessor(t, root)
+	zipped.Gzip = true
+	for _, p := range []*ProjectProcessor{plain, zipped} {
+		if err := p.Process(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := readOutputs(t, plain.OutputPath)
+	entries, err := os.ReadDir(zipped.OutputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(want) {
+		t.Errorf("wrote %d gzipped files, want %d", len(entries), len(want))
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".gz")
+		if !ok {
+			t.Errorf("%s has no .gz extension", entry.Name())
+			continue
+		}
+		f, err := os.Open(filepath.Join(zipped.OutputPath, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("%s: %v", entry.Name(), err)
+		}
+		b, err := io.ReadAll(zr)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", entry.Name(), err)
+		}
+		got := generatedAtPattern.ReplaceAllString(string(b), `"generated_at":""`)
+		if got != want[name] {
+			t.Errorf("%s does not decompress to %s:\n%s\nwant\n%s", entry.Name(), name, got, want[name])
+		}
+	}
+}
+
+func TestSignaturesFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "empty",
+			files: map[string]string{"a.go": "package a\n"},
+			want:  nil,
+		},
+		{
+			name: "functions and methods",
+			files: map[string]string{
+				"store/store.go": `package store
+
+// Open opens a store.
+func Open(path string, readOnly bool) (*Store, error) {
+	return nil, nil
+}
+
+func (s *Store) Close() error { return nil }
+
+func Map[T any](xs []T, f func(T) T) []T { return xs }
+
+type Store struct{}
+`,
+				"store/store_test.go": "package store\n\nimport \"testing\"\n\nfunc TestOpen(t *testing.T) {}\n",
+			},
+			want: []string{
+				"store.Open(path string, readOnly bool) (*Store, error)",
+				"store.(s *Store) Close() error",
+				"store.Map[T any](xs []T, f func(T) T) []T",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := processProject(t, tt.files, func(p *ProjectProcessor) {
+				p.Format = formatSignatures
+			})
+			b, err := os.ReadFile(filepath.Join(out, "signatures.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if len(b) > 0 {
+				got = strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signatures = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocsFormat(t *testing.T) {
+	out := processProject(t, map[string]string{
+		"server/server.go": `package server
+
+// Serve serves.
+//
+// It blocks.
+func Serve(addr string) error {
+	listen()
+	return nil
+}
+
+func listen() {}
+`,
+		"server/server_test.go": "package server\n\nimport \"testing\"\n\nfunc TestServe(t *testing.T) {}\n",
+	}, func(p *ProjectProcessor) {
+		p.Format = formatDocs
+	})
+	b, err := os.ReadFile(filepath.Join(out, "docs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var docs []map[string]interface{}
+	if err := json.Unmarshal(b, &docs); err != nil {
+		t.Fatalf("docs.json is not a JSON array: %v\n%s", err, b)
+	}
+	want := []map[string]interface{}{
+		{"name": "Serve", "package": "server", "doc": "Serve serves.\n\nIt blocks.\n"},
+		{"name": "listen", "package": "server", "doc": ""},
+	}
+	if !reflect.DeepEqual(docs, want) {
+		t.Errorf("docs = %v, want %v", docs, want)
+	}
+	for _, field := range []string{"signature", "calls", "body"} {
+		if strings.Contains(string(b), `"`+field+`"`) {
+			t.Errorf("docs.json has a %s field", field)
+		}
+	}
+}
+
+func TestDocsOnlyFlag(t *testing.T) {
+	root := writeProject(t, map[string]string{"a.go": "package a\n\n// A is a.\nfunc A() {}\n"})
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"docs only", []string{"--docs-only"}, ""},
+		{"with docs format", []string{"--docs-only", "--format", formatDocs}, ""},
+		{"with another format", []string{"--docs-only", "--format", formatJSON}, "--docs-only cannot be combined with --format json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := t.TempDir()
+			err := createCliApp().Run(append([]string{"parse", "--project", root, "--output", out}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(out, "docs.json")); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
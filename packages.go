@@ -0,0 +1,56 @@
+package main
+
+import (
+	"go/ast"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PackageDescription collects the package doc comments of all files that
+// belong to the same package in the same directory of the same project root.
+type PackageDescription struct {
+	Name  string   `json:"name" yaml:"name"`
+	Root  string   `json:"root" yaml:"root"`
+	Dir   string   `json:"dir" yaml:"dir"`
+	Doc   string   `json:"doc" yaml:"doc"`
+	Files []string `json:"files" yaml:"files"`
+}
+
+func describePackage(p Param, file *ast.File) PackageDescription {
+	return PackageDescription{
+		Name:  file.Name.Name,
+		Root:  filepath.ToSlash(p.Root),
+		Dir:   path.Dir(p.FilePath),
+		Doc:   strings.TrimSpace(file.Doc.Text()),
+		Files: []string{p.FilePath},
+	}
+}
+
+func mergePackageDescriptions(packages, others []PackageDescription) []PackageDescription {
+	for _, other := range others {
+		i := indexPackage(packages, other.Name, other.Root, other.Dir)
+		if i < 0 {
+			packages = append(packages, other)
+			continue
+		}
+		pkg := &packages[i]
+		pkg.Files = append(pkg.Files, other.Files...)
+		if other.Doc != "" && !strings.Contains(pkg.Doc, other.Doc) {
+			if pkg.Doc != "" {
+				pkg.Doc += "\n\n"
+			}
+			pkg.Doc += other.Doc
+		}
+	}
+	return packages
+}
+
+func indexPackage(packages []PackageDescription, name, root, dir string) int {
+	for i, pkg := range packages {
+		if pkg.Name == name && pkg.Root == root && pkg.Dir == dir {
+			return i
+		}
+	}
+	return -1
+}
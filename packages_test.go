@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribePackages(t *testing.T) {
+	sources := []struct{ name, src string }{
+		{"store/doc.go", "// Package store keeps values.\npackage store\n"},
+		{"store/store.go", "package store\n\nfunc Get() {}\n"},
+		{"store/more.go", "// Package store keeps values.\npackage store\n"},
+		{"store/extra.go", "// It is safe for concurrent use.\npackage store\n"},
+		{"cmd/main.go", "package main\n"},
+	}
+	var funcs Func
+	for _, s := range sources {
+		funcs.Merge(parseTestSource(t, s.name, s.src, Param{}))
+	}
+	want := []PackageDescription{
+		{
+			Name:  "store",
+			Dir:   "store",
+			Doc:   "Package store keeps values.\n\nIt is safe for concurrent use.",
+			Files: []string{"store/doc.go", "store/store.go", "store/more.go", "store/extra.go"},
+		},
+		{Name: "main", Dir: "cmd", Files: []string{"cmd/main.go"}},
+	}
+	if !reflect.DeepEqual(funcs.PackageDescriptions, want) {
+		t.Errorf("packages = %+v\nwant %+v", funcs.PackageDescriptions, want)
+	}
+}
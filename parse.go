@@ -1,52 +1,221 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
+	"go/printer"
 	"go/token"
+	"go/types"
 	"io"
-	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type Func struct {
 	FullDescriptions         []string
 	FunctionDescriptions     []FunctionDescription
 	TestFunctionDescriptions []FunctionDescription
+	FileDescriptions         []FileDescription
+	TypeDescriptions         []TypeDescription
+	DeclDescriptions         []DeclDescription
+	PackageDescriptions      []PackageDescription
+	TodoDescriptions         []TodoDescription
+	IgnoredErrorDescriptions []IgnoredErrorDescription
+	DocDescriptions          []DocDescription
+}
+
+type FileDescription struct {
+	FilePath string   `json:"file_path" yaml:"file_path"`
+	Root     string   `json:"root" yaml:"root"`
+	FileName string   `json:"file_name" yaml:"file_name"`
+	Package  string   `json:"package" yaml:"package"`
+	Imports  []string `json:"imports" yaml:"imports"`
 }
 
 type FunctionDescription struct {
-	Name           string `json:"name"`
-	Doc            string `json:"doc"`
-	Package        string `json:"package"`
-	IsTestFunction bool   `json:"is_test_function"`
+	Name            string                 `json:"name" yaml:"name"`
+	Doc             string                 `json:"doc" yaml:"doc"`
+	Signature       string                 `json:"signature" yaml:"signature"`
+	Package         string                 `json:"package" yaml:"package"`
+	FilePath        string                 `json:"file_path" yaml:"file_path"`
+	Root            string                 `json:"root" yaml:"root"`
+	IsTestFunction  bool                   `json:"is_test_function" yaml:"is_test_function"`
+	Kind            string                 `json:"kind" yaml:"kind"`
+	Receiver        string                 `json:"receiver" yaml:"receiver"`
+	ReceiverType    string                 `json:"receiver_type" yaml:"receiver_type"`
+	IsMethod        bool                   `json:"is_method" yaml:"is_method"`
+	StartLine       int                    `json:"start_line" yaml:"start_line"`
+	EndLine         int                    `json:"end_line" yaml:"end_line"`
+	StartCol        int                    `json:"start_col" yaml:"start_col"`
+	Calls           []string               `json:"calls" yaml:"calls"`
+	Complexity      int                    `json:"complexity" yaml:"complexity"`
+	LineCount       int                    `json:"line_count" yaml:"line_count"`
+	Deprecated      bool                   `json:"deprecated" yaml:"deprecated"`
+	DeprecationNote string                 `json:"deprecation_note" yaml:"deprecation_note"`
+	HasDoc          bool                   `json:"has_doc" yaml:"has_doc"`
+	Closures        []ClosureDescription   `json:"closures,omitempty" yaml:"closures,omitempty"`
+	ReferencedTypes []string               `json:"referenced_types" yaml:"referenced_types"`
+	Exported        bool                   `json:"exported" yaml:"exported"`
+	Warnings        []string               `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	ReturnsError    bool                   `json:"returns_error" yaml:"returns_error"`
+	AcceptsContext  bool                   `json:"accepts_context" yaml:"accepts_context"`
+	GoStatements    int                    `json:"go_statements" yaml:"go_statements"`
+	Defers          int                    `json:"defers" yaml:"defers"`
+	CallsPanic      bool                   `json:"calls_panic" yaml:"calls_panic"`
+	Parameters      []ParameterDescription `json:"parameters" yaml:"parameters"`
+	Results         []ParameterDescription `json:"results" yaml:"results"`
+	ParamCount      int                    `json:"param_count" yaml:"param_count"`
+	ResultCount     int                    `json:"result_count" yaml:"result_count"`
+	TypeParams      []TypeParam            `json:"type_params,omitempty" yaml:"type_params,omitempty"`
 }
 
+type TypeParam struct {
+	Name       string `json:"name" yaml:"name"`
+	Constraint string `json:"constraint" yaml:"constraint"`
+}
+
+// ParameterDescription is a single parameter or result of a function. A type
+// is external when it refers to another package through a selector.
+type ParameterDescription struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	External bool   `json:"external" yaml:"external"`
+}
+
+// ClosureDescription is a function literal found in the body of a function.
+type ClosureDescription struct {
+	Signature string `json:"signature" yaml:"signature"`
+	StartLine int    `json:"start_line" yaml:"start_line"`
+	EndLine   int    `json:"end_line" yaml:"end_line"`
+	StartCol  int    `json:"start_col" yaml:"start_col"`
+}
+
+const (
+	kindRegular   = "regular"
+	kindTest      = "test"
+	kindBenchmark = "benchmark"
+	kindExample   = "example"
+	kindFuzz      = "fuzz"
+)
+
+// functionEndSuffix terminates every function in the description text, which
+// lets the text be split without cutting a function in half.
+const functionEndSuffix = "  ###`\n"
+
 type Param struct {
-	FilePath    string
-	FileName    string
-	IncludeBody bool
+	FilePath         string
+	FileName         string
+	Root             string
+	IncludeBody      bool
+	ExportedOnly     bool
+	UndocumentedOnly bool
+	GroupMethods     bool
+	MarkVisibility   bool
+	MinComplexity    int
+	ExcludeFuncs     *regexp.Regexp
+	IncludeFuncs     *regexp.Regexp
+	Packages         []string
+	Fset             *token.FileSet
+}
+
+type source struct {
+	fset *token.FileSet
+	file *token.File
+	code string
 }
 
-func (f *Func) ParseFunctions(p Param) {
+func (f *Func) ParseFunctions(ctx context.Context, p Param) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	code, err := readFile(p.FilePath)
 	if err != nil {
-		log.Printf("Error reading file %s: %v", p.FilePath, err)
-		return
+		return fmt.Errorf("error reading file %s: %w", p.FilePath, err)
+	}
+	p.FilePath = p.outputPath()
+	return f.ParseSource(p.FileName, code, p)
+}
+
+// outputPath is the path recorded in the descriptions: relative to the
+// project root and always with forward slashes.
+func (p Param) outputPath() string {
+	if p.Root == "" {
+		return filepath.ToSlash(p.FilePath)
+	}
+	if p.FilePath == p.Root {
+		return filepath.Base(p.FilePath)
 	}
+	rel, err := filepath.Rel(p.Root, p.FilePath)
+	if err != nil {
+		return filepath.ToSlash(p.FilePath)
+	}
+	return filepath.ToSlash(rel)
+}
 
-	file, err := parseCode(p.FileName, code)
+func (f *Func) ParseSource(fileName, code string, p Param) error {
+	if p.FileName == "" {
+		p.FileName = fileName
+	}
+	if p.FilePath == "" {
+		p.FilePath = fileName
+	}
+
+	fset := p.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+
+	file, err := parseCode(fset, fileName, code)
 	if err != nil {
-		log.Printf("Error parsing file %s: %v", p.FileName, err)
-		return
+		return fmt.Errorf("error parsing file %s: %w", p.FilePath, err)
+	}
+
+	if !p.includesPackage(file.Name.Name) {
+		return nil
 	}
 
-	description, funcDescriptions, testFuncDescriptions := buildFileDescription(p, file, code)
-	f.FullDescriptions = append(f.FullDescriptions, description)
-	f.FunctionDescriptions = append(f.FunctionDescriptions, funcDescriptions...)
-	f.TestFunctionDescriptions = append(f.TestFunctionDescriptions, testFuncDescriptions...)
+	src := source{fset: fset, file: fset.File(file.Pos()), code: code}
+	f.Merge(buildFileDescription(p, file, src))
+	return nil
+}
+
+func (f *Func) Merge(other Func) {
+	f.FullDescriptions = append(f.FullDescriptions, other.FullDescriptions...)
+	f.FunctionDescriptions = append(f.FunctionDescriptions, other.FunctionDescriptions...)
+	f.TestFunctionDescriptions = append(f.TestFunctionDescriptions, other.TestFunctionDescriptions...)
+	f.FileDescriptions = append(f.FileDescriptions, other.FileDescriptions...)
+	f.TypeDescriptions = append(f.TypeDescriptions, other.TypeDescriptions...)
+	f.DeclDescriptions = append(f.DeclDescriptions, other.DeclDescriptions...)
+	f.PackageDescriptions = mergePackageDescriptions(f.PackageDescriptions, other.PackageDescriptions)
+	f.TodoDescriptions = append(f.TodoDescriptions, other.TodoDescriptions...)
+	f.IgnoredErrorDescriptions = append(f.IgnoredErrorDescriptions, other.IgnoredErrorDescriptions...)
+	f.DocDescriptions = append(f.DocDescriptions, other.DocDescriptions...)
+}
+
+// Sort orders the function descriptions by file path and then by line, so
+// the output does not depend on the order files were discovered in.
+func (f *Func) Sort() {
+	sortFunctionDescriptions(f.FunctionDescriptions)
+	sortFunctionDescriptions(f.TestFunctionDescriptions)
+}
+
+func sortFunctionDescriptions(descriptions []FunctionDescription) {
+	sort.SliceStable(descriptions, func(i, j int) bool {
+		if descriptions[i].FilePath != descriptions[j].FilePath {
+			return descriptions[i].FilePath < descriptions[j].FilePath
+		}
+		return descriptions[i].StartLine < descriptions[j].StartLine
+	})
 }
 
 func (f *Func) Print() {
@@ -55,6 +224,10 @@ func (f *Func) Print() {
 	}
 }
 
+func (p Param) includesPackage(name string) bool {
+	return len(p.Packages) == 0 || slices.Contains(p.Packages, name)
+}
+
 func readFile(filePath string) (string, error) {
 	codeFile, err := os.Open(filePath)
 	if err != nil {
@@ -69,41 +242,289 @@ func readFile(filePath string) (string, error) {
 	return string(srcbuf), nil
 }
 
-func parseCode(fileName, code string) (*ast.File, error) {
-	fset := token.NewFileSet()
+func parseCode(fset *token.FileSet, fileName, code string) (*ast.File, error) {
 	return parser.ParseFile(fset, fileName, code, parser.ParseComments)
 }
 
-func buildFileDescription(p Param, file *ast.File, code string) (string, []FunctionDescription, []FunctionDescription) {
+func buildFileDescription(p Param, file *ast.File, src source) Func {
 	var sb strings.Builder
 	var funcDescriptions, testFuncDescriptions []FunctionDescription
+	var ignored []IgnoredErrorDescription
+	var docs []DocDescription
+	localErrorFuncs := errorFuncs(file)
 
-	isTestFile := strings.Contains(p.FileName, "_test")
-	writeFileHeader(&sb, p, file, isTestFile)
+	isTestFile := isTestFileName(p.FileName)
+	imports := fileImports(file)
+	writeFileHeader(&sb, p, file, isTestFile, imports)
+	groups := methodGroups{texts: make(map[string][]string)}
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		if fn, ok := n.(*ast.FuncDecl); ok {
-			funcStr := describeFunctionDeclaration(&sb, fn, code, p.IncludeBody)
+			if p.ExportedOnly && !isExportedFunc(fn) {
+				return true
+			}
+			if p.ExcludeFuncs != nil && p.ExcludeFuncs.MatchString(fn.Name.Name) {
+				return true
+			}
+			if p.IncludeFuncs != nil && !p.IncludeFuncs.MatchString(fn.Name.Name) {
+				return true
+			}
+			hasDoc := fn.Doc != nil && strings.TrimSpace(fn.Doc.Text()) != ""
+			if p.UndocumentedOnly && hasDoc {
+				return true
+			}
+			complexity := computeComplexity(fn)
+			if complexity < p.MinComplexity {
+				return true
+			}
+			var funcSb strings.Builder
+			funcStr := describeFunctionDeclaration(&funcSb, fn, src, p)
+			if p.GroupMethods {
+				groups.add(receiverTypeName(fn.Recv), funcSb.String())
+			} else {
+				sb.WriteString(funcSb.String())
+			}
+			start := src.file.Position(fn.Pos())
+			end := src.file.Position(fn.End())
 			funcDesc := FunctionDescription{
-				Name:           fn.Name.Name,
-				Doc:            funcStr,
-				Package:        file.Name.Name,
-				IsTestFunction: isTestFile,
+				Name:            fn.Name.Name,
+				Doc:             funcStr,
+				Signature:       functionSignature(fn),
+				Package:         file.Name.Name,
+				FilePath:        p.FilePath,
+				Root:            filepath.ToSlash(p.Root),
+				IsTestFunction:  isTestFile,
+				Kind:            functionKind(fn, isTestFile),
+				IsMethod:        fn.Recv != nil,
+				StartLine:       start.Line,
+				EndLine:         end.Line,
+				StartCol:        start.Column,
+				Calls:           functionCalls(fn, src),
+				ReferencedTypes: referencedTypes(fn),
+				Complexity:      complexity,
+				LineCount:       end.Line - start.Line + 1,
+				HasDoc:          hasDoc,
+				Exported:        isExportedFunc(fn),
+				Warnings:        typeWarnings(fn, src),
+				ReturnsError:    returnsError(fn),
+				AcceptsContext:  acceptsContext(fn),
+			}
+			funcDesc.DeprecationNote, funcDesc.Deprecated = deprecationNote(fn.Doc)
+			funcDesc.GoStatements, funcDesc.Defers = countGoAndDefer(fn)
+			funcDesc.CallsPanic = callsPanic(fn)
+			funcDesc.Parameters = describeParameters(fn.Type.Params)
+			funcDesc.Results = describeParameters(fn.Type.Results)
+			funcDesc.ParamCount = len(funcDesc.Parameters)
+			funcDesc.ResultCount = len(funcDesc.Results)
+			funcDesc.TypeParams = describeTypeParams(fn.Type.TypeParams)
+			ignored = append(ignored, ignoredErrors(fn, src, p.FilePath, localErrorFuncs)...)
+			if p.IncludeBody {
+				funcDesc.Closures = functionClosures(fn, src)
+			}
+			if fn.Recv != nil {
+				funcDesc.Receiver = fields(*fn.Recv)
+				funcDesc.ReceiverType = receiverTypeName(fn.Recv)
 			}
 			if isTestFile {
 				testFuncDescriptions = append(testFuncDescriptions, funcDesc)
 			} else {
 				funcDescriptions = append(funcDescriptions, funcDesc)
+				docs = append(docs, DocDescription{Name: funcDesc.Name, Package: funcDesc.Package, Doc: fn.Doc.Text()})
 			}
 		}
 		return true
 	})
 
+	if p.GroupMethods {
+		groups.write(&sb)
+	}
 	writeFileFooter(&sb, p, isTestFile)
-	return sb.String(), funcDescriptions, testFuncDescriptions
+	return Func{
+		FullDescriptions:         []string{sb.String()},
+		FunctionDescriptions:     funcDescriptions,
+		TestFunctionDescriptions: testFuncDescriptions,
+		FileDescriptions: []FileDescription{{
+			FilePath: p.FilePath,
+			Root:     filepath.ToSlash(p.Root),
+			FileName: p.FileName,
+			Package:  file.Name.Name,
+			Imports:  imports,
+		}},
+		TypeDescriptions:         describeTypes(p, file, src),
+		DeclDescriptions:         describeDecls(p, file, src),
+		PackageDescriptions:      []PackageDescription{describePackage(p, file)},
+		TodoDescriptions:         describeTodos(p, file, src),
+		IgnoredErrorDescriptions: ignored,
+		DocDescriptions:          docs,
+	}
+}
+
+// methodGroups collects function descriptions by receiver type, in the order
+// the types are first seen. Free functions are kept under the empty name.
+type methodGroups struct {
+	order []string
+	texts map[string][]string
+}
+
+func (g *methodGroups) add(recvType, text string) {
+	if _, ok := g.texts[recvType]; !ok {
+		g.order = append(g.order, recvType)
+	}
+	g.texts[recvType] = append(g.texts[recvType], text)
+}
+
+func (g *methodGroups) write(sb *strings.Builder) {
+	if texts, ok := g.texts[""]; ok {
+		sb.WriteString("###Functions\n")
+		for _, text := range texts {
+			sb.WriteString(text)
+		}
+	}
+	for _, recvType := range g.order {
+		if recvType == "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("###Methods of type %s\n", recvType))
+		for _, text := range g.texts[recvType] {
+			sb.WriteString(text)
+		}
+	}
+}
+
+func fileImports(file *ast.File) []string {
+	imports := make([]string, 0, len(file.Imports))
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			path = spec.Path.Value
+		}
+		if spec.Name != nil {
+			imports = append(imports, spec.Name.Name+" "+path)
+			continue
+		}
+		imports = append(imports, path)
+	}
+	return imports
 }
 
-func writeFileHeader(sb *strings.Builder, p Param, file *ast.File, isTestFile bool) {
+func isExportedFunc(fn *ast.FuncDecl) bool {
+	if !fn.Name.IsExported() {
+		return false
+	}
+	if fn.Recv != nil {
+		return ast.IsExported(receiverTypeName(fn.Recv))
+	}
+	return true
+}
+
+// receiverTypeName returns the bare type name of a method receiver, without
+// pointer or type arguments.
+func receiverTypeName(recv *ast.FieldList) string {
+	if recv == nil || len(recv.List) == 0 {
+		return ""
+	}
+	return baseTypeName(recv.List[0].Type)
+}
+
+// receiverTypeParams returns the names a generic receiver gives to the type
+// parameters of its base type, such as T and U in (m *Map[T, U]).
+func receiverTypeParams(recv *ast.FieldList) []string {
+	if recv == nil || len(recv.List) == 0 {
+		return nil
+	}
+	t := recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.ParenExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			return identNames([]ast.Expr{x.Index})
+		case *ast.IndexListExpr:
+			return identNames(x.Indices)
+		default:
+			return nil
+		}
+	}
+}
+
+func identNames(exprs []ast.Expr) []string {
+	var names []string
+	for _, e := range exprs {
+		if id, ok := e.(*ast.Ident); ok {
+			names = append(names, id.Name)
+		}
+	}
+	return names
+}
+
+func baseTypeName(t ast.Expr) string {
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.ParenExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		case *ast.SelectorExpr:
+			return x.Sel.Name
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
+
+func isTestFileName(name string) bool {
+	return strings.HasSuffix(name, "_test.go")
+}
+
+func functionKind(fn *ast.FuncDecl, isTestFile bool) string {
+	if !isTestFile || fn.Recv != nil {
+		return kindRegular
+	}
+
+	name := fn.Name.Name
+	switch {
+	case hasTestPrefix(name, "Test") && hasSingleParam(fn, "*testing.T"):
+		return kindTest
+	case hasTestPrefix(name, "Benchmark") && hasSingleParam(fn, "*testing.B"):
+		return kindBenchmark
+	case hasTestPrefix(name, "Fuzz") && hasSingleParam(fn, "*testing.F"):
+		return kindFuzz
+	case hasTestPrefix(name, "Example") && fn.Type.Params.NumFields() == 0 && fn.Type.Results.NumFields() == 0:
+		return kindExample
+	}
+	return kindRegular
+}
+
+// hasTestPrefix mirrors the go tool's rule: the prefix must be followed by
+// nothing or by a character that is not a lower-case letter.
+func hasTestPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+func hasSingleParam(fn *ast.FuncDecl, typ string) bool {
+	params := fn.Type.Params
+	if params.NumFields() != 1 || fn.Type.Results.NumFields() != 0 {
+		return false
+	}
+	return expr(params.List[0].Type) == typ
+}
+
+func writeFileHeader(sb *strings.Builder, p Param, file *ast.File, isTestFile bool, imports []string) {
 	fileType := "go"
 	if isTestFile {
 		fileType += " test"
@@ -112,9 +533,20 @@ func writeFileHeader(sb *strings.Builder, p Param, file *ast.File, isTestFile bo
 	sb.WriteString(fmt.Sprintf("###File path: %s\n", p.FilePath))
 	sb.WriteString(fmt.Sprintf("###File name: %s\n", p.FileName))
 	sb.WriteString(fmt.Sprintf("##Package name: %s\n", file.Name.Name))
+	writeImports(sb, imports)
 	sb.WriteString(fmt.Sprintf("##%s\n", strings.Title(fileType)+" Functions"))
 }
 
+func writeImports(sb *strings.Builder, imports []string) {
+	if len(imports) == 0 {
+		return
+	}
+	sb.WriteString("##Imports\n")
+	for _, imp := range imports {
+		sb.WriteString("  " + imp + "\n")
+	}
+}
+
 func writeFileFooter(sb *strings.Builder, p Param, isTestFile bool) {
 	fileType := "go"
 	if isTestFile {
@@ -123,39 +555,63 @@ func writeFileFooter(sb *strings.Builder, p Param, isTestFile bool) {
 	sb.WriteString(fmt.Sprintf("----- End of %s file %s -------\n", fileType, p.FilePath))
 }
 
-func describeFunctionDeclaration(funcSb *strings.Builder, fn *ast.FuncDecl, code string, includeBody bool) string {
+func describeFunctionDeclaration(funcSb *strings.Builder, fn *ast.FuncDecl, src source, p Param) string {
 	var sb strings.Builder
 	writeComments(&sb, fn.Doc)
-	sb.WriteString(fmt.Sprintf("##Function name: %s\n", fn.Name.Name))
+	sb.WriteString("##Function name: ")
+	if p.MarkVisibility {
+		if isExportedFunc(fn) {
+			sb.WriteString("[exported] ")
+		} else {
+			sb.WriteString("[unexported] ")
+		}
+	}
+	sb.WriteString(fn.Name.Name + "\n")
 
 	if fn.Recv != nil {
 		sb.WriteString(fmt.Sprintf("##Receiver: \n%s\n", fields(*fn.Recv)))
 	}
 
+	writeTypeParams(&sb, fn.Type.TypeParams)
 	writeParameters(&sb, fn.Type.Params)
 	writeResults(&sb, fn.Type.Results)
-	writeFunctionCalls(&sb, fn, code)
+	writeFunctionCalls(&sb, fn, src)
 
-	if includeBody {
-		writeFunctionBody(&sb, fn, code)
+	// The body only goes into the full description text; the returned doc
+	// used for the JSON outputs stays the same regardless of IncludeBody.
+	var body strings.Builder
+	if p.IncludeBody {
+		writeFunctionBody(&body, fn, src)
 	}
 
-	sb.WriteString(fmt.Sprintf("`###End of function with name %s  ###`\n", fn.Name.Name))
-	funcSb.WriteString(sb.String())
-	return sb.String()
+	end := fmt.Sprintf("`###End of function with name %s"+functionEndSuffix, fn.Name.Name)
+	funcSb.WriteString(sb.String() + body.String() + end)
+	return sb.String() + end
 }
 
+// writeComments writes the text of doc without comment markers. Text keeps
+// the blank lines between paragraphs and the indentation of lists and code
+// blocks, and works the same for // and /* */ comments.
 func writeComments(sb *strings.Builder, doc *ast.CommentGroup) {
 	if doc != nil {
-		for _, c := range doc.List {
-			sb.WriteString(c.Text + "\n")
-		}
+		sb.WriteString(doc.Text())
+	}
+}
+
+func writeTypeParams(sb *strings.Builder, typeParams *ast.FieldList) {
+	if typeParams != nil {
+		sb.WriteString("##Type Parameters: " + fields(*typeParams) + "\n")
 	}
 }
 
 func writeParameters(sb *strings.Builder, params *ast.FieldList) {
-	if params != nil {
-		sb.WriteString("##Parameters: " + fields(*params) + "\n")
+	if params == nil {
+		return
+	}
+	if list := fields(*params); list != "" {
+		sb.WriteString("##Parameters: " + list + "\n")
+	} else {
+		sb.WriteString("##Parameters:\n")
 	}
 }
 
@@ -165,23 +621,254 @@ func writeResults(sb *strings.Builder, results *ast.FieldList) {
 	}
 }
 
-func writeFunctionCalls(sb *strings.Builder, fn *ast.FuncDecl, code string) {
+func writeFunctionCalls(sb *strings.Builder, fn *ast.FuncDecl, src source) {
 	sb.WriteString("## Function calls from other packages\n")
 	sb.WriteString("```go\n")
 	ast.Inspect(fn, func(n ast.Node) bool {
 		if call, ok := n.(*ast.CallExpr); ok {
-			sb.WriteString("  " + code[call.Pos()-1:call.End()-1] + "\n")
+			sb.WriteString("  " + src.text(call) + "\n")
 		}
 		return true
 	})
 	sb.WriteString("```\n")
 }
 
-func writeFunctionBody(sb *strings.Builder, fn *ast.FuncDecl, code string) {
+func functionCalls(fn *ast.FuncDecl, src source) []string {
+	var calls []string
+	seen := make(map[string]bool)
+	ast.Inspect(fn, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if _, isLit := call.Fun.(*ast.FuncLit); isLit {
+			return true
+		}
+		callee := src.text(call.Fun)
+		if !seen[callee] {
+			seen[callee] = true
+			calls = append(calls, callee)
+		}
+		return true
+	})
+	return calls
+}
+
+func functionClosures(fn *ast.FuncDecl, src source) []ClosureDescription {
+	if fn.Body == nil {
+		return nil
+	}
+	var closures []ClosureDescription
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			start := src.file.Position(lit.Pos())
+			closures = append(closures, ClosureDescription{
+				Signature: "func" + signature(lit.Type),
+				StartLine: start.Line,
+				EndLine:   src.file.Position(lit.End()).Line,
+				StartCol:  start.Column,
+			})
+		}
+		return true
+	})
+	return closures
+}
+
+// referencedTypes lists the named types a function mentions in its
+// signature and in type positions of its body: declarations, composite
+// literals, type assertions, type switches and make or new calls.
+// Predeclared identifiers and the type parameters of the function or of its
+// receiver, as in func (l *List[T]) Push(v T), are left out.
+func referencedTypes(fn *ast.FuncDecl) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	typeParams := make(map[string]bool)
+	if fn.Type.TypeParams != nil {
+		for _, f := range fn.Type.TypeParams.List {
+			for _, n := range f.Names {
+				typeParams[n.Name] = true
+			}
+		}
+	}
+	for _, name := range receiverTypeParams(fn.Recv) {
+		typeParams[name] = true
+	}
+
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	var addType func(e ast.Expr)
+	addType = func(e ast.Expr) {
+		if e == nil {
+			return
+		}
+		ast.Inspect(e, func(n ast.Node) bool {
+			switch t := n.(type) {
+			case *ast.SelectorExpr:
+				add(expr(t))
+				return false
+			case *ast.Ident:
+				if !typeParams[t.Name] && !isPredeclared(t.Name) {
+					add(t.Name)
+				}
+				return false
+			case *ast.ArrayType:
+				addType(t.Elt)
+				return false
+			case *ast.Field:
+				addType(t.Type)
+				return false
+			}
+			return true
+		})
+	}
+	addFields := func(fl *ast.FieldList) {
+		if fl != nil {
+			for _, f := range fl.List {
+				addType(f.Type)
+			}
+		}
+	}
+
+	addFields(fn.Recv)
+	addFields(fn.Type.Params)
+	addFields(fn.Type.Results)
+	if fn.Body == nil {
+		return refs
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.ValueSpec:
+			addType(t.Type)
+		case *ast.CompositeLit:
+			addType(t.Type)
+		case *ast.TypeAssertExpr:
+			addType(t.Type)
+		case *ast.FuncLit:
+			addFields(t.Type.Params)
+			addFields(t.Type.Results)
+		case *ast.TypeSwitchStmt:
+			for _, stmt := range t.Body.List {
+				for _, e := range stmt.(*ast.CaseClause).List {
+					addType(e)
+				}
+			}
+		case *ast.CallExpr:
+			if id, ok := t.Fun.(*ast.Ident); ok && (id.Name == "make" || id.Name == "new") && len(t.Args) > 0 {
+				addType(t.Args[0])
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+func isPredeclared(name string) bool {
+	return types.Universe.Lookup(name) != nil
+}
+
+// deprecationNote looks for a "Deprecated:" line in doc and returns its
+// message, including any continuation lines up to the end of the paragraph.
+func deprecationNote(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+
+	var note []string
+	found := false
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !found {
+			if rest, ok := strings.CutPrefix(line, "Deprecated:"); ok {
+				found = true
+				if rest = strings.TrimSpace(rest); rest != "" {
+					note = append(note, rest)
+				}
+			}
+			continue
+		}
+		if line == "" {
+			break
+		}
+		note = append(note, line)
+	}
+	return strings.Join(note, " "), found
+}
+
+// computeComplexity returns the cyclomatic complexity of fn: one plus the
+// number of decision points in its body.
+func computeComplexity(fn *ast.FuncDecl) int {
+	complexity := 1
+	if fn.Body == nil {
+		return complexity
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if x.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if x.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
+
+func writeFunctionBody(sb *strings.Builder, fn *ast.FuncDecl, src source) {
 	sb.WriteString(fmt.Sprintf("####Function Body of function %s\n", fn.Name.Name))
 	sb.WriteString("```go\n")
-	sb.WriteString(code[fn.Pos()-1 : fn.End()-1])
-	sb.WriteString("```\n")
+	sb.WriteString(src.text(fn))
+	sb.WriteString("\n```\n")
+}
+
+// text returns the source of n. Slicing the original code keeps the author's
+// formatting, but when n's positions do not map cleanly onto code the node is
+// printed from the AST instead.
+func (s source) text(n ast.Node) string {
+	if start, end, ok := s.offsets(n); ok {
+		return s.code[start:end]
+	}
+
+	var buf strings.Builder
+	if err := printer.Fprint(&buf, s.fset, n); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+func (s source) offsets(n ast.Node) (int, int, bool) {
+	if s.file == nil || !n.Pos().IsValid() || !n.End().IsValid() {
+		return 0, 0, false
+	}
+	base := s.file.Base()
+	if int(n.Pos()) < base || int(n.End()) > base+s.file.Size() {
+		return 0, 0, false
+	}
+
+	start, end := s.file.Offset(n.Pos()), s.file.Offset(n.End())
+	if start > end || end > len(s.code) {
+		return 0, 0, false
+	}
+	if start < len(s.code) && !utf8.RuneStart(s.code[start]) {
+		return 0, 0, false
+	}
+	if end < len(s.code) && !utf8.RuneStart(s.code[end]) {
+		return 0, 0, false
+	}
+	return start, end, true
 }
 
 func expr(e ast.Expr) string {
@@ -199,10 +886,105 @@ func expr(e ast.Expr) string {
 		return fmt.Sprintf("map[%s]%s", expr(x.Key), expr(x.Value))
 	case *ast.SelectorExpr:
 		return fmt.Sprintf("%s.%s", expr(x.X), expr(x.Sel))
+	case *ast.ChanType:
+		switch x.Dir {
+		case ast.RECV:
+			return fmt.Sprintf("<-chan %s", expr(x.Value))
+		case ast.SEND:
+			return fmt.Sprintf("chan<- %s", expr(x.Value))
+		default:
+			return fmt.Sprintf("chan %s", expr(x.Value))
+		}
+	case *ast.FuncType:
+		return "func" + signature(x)
+	case *ast.ParenExpr:
+		// Parentheses can change the meaning of a type, as in chan (<-chan int),
+		// so they are only dropped around plain names.
+		switch x.X.(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+			return expr(x.X)
+		}
+		return "(" + expr(x.X) + ")"
+	case *ast.BasicLit:
+		return x.Value
+	case *ast.Ellipsis:
+		return fmt.Sprintf("...%s", expr(x.Elt))
+	case *ast.InterfaceType:
+		return interfaceType(x)
+	case *ast.StructType:
+		return structType(x)
+	case *ast.IndexExpr:
+		return fmt.Sprintf("%s[%s]", expr(x.X), expr(x.Index))
+	case *ast.IndexListExpr:
+		indices := make([]string, len(x.Indices))
+		for i, index := range x.Indices {
+			indices[i] = expr(index)
+		}
+		return fmt.Sprintf("%s[%s]", expr(x.X), strings.Join(indices, ", "))
+	case nil:
+		return ""
 	default:
-		log.Printf("Unknown type: %T\n", x)
+		return printExpr(x)
+	}
+}
+
+// printExpr renders expressions that expr has no dedicated case for. The
+// node is printed without position information so it stays on one line.
+func printExpr(e ast.Expr) string {
+	var buf strings.Builder
+	if err := printer.Fprint(&buf, token.NewFileSet(), e); err != nil {
 		return ""
 	}
+	return buf.String()
+}
+
+// typeWarnings reports the types in the signature of fn that expr has no case
+// for. They are rendered by go/printer instead, or not at all, so consumers
+// know the signature may differ from the source or be incomplete.
+func typeWarnings(fn *ast.FuncDecl, src source) []string {
+	var warnings []string
+	for _, fl := range []*ast.FieldList{fn.Recv, fn.Type.TypeParams, fn.Type.Params, fn.Type.Results} {
+		if fl == nil {
+			continue
+		}
+		ast.Inspect(fl, func(n ast.Node) bool {
+			e, ok := n.(ast.Expr)
+			if !ok {
+				return true
+			}
+			if exprHandles(e) {
+				return true
+			}
+			pos := src.file.Position(e.Pos())
+			if err := renderError(e); err != nil {
+				warnings = append(warnings, fmt.Sprintf("%d:%d: unable to render type %T: %v", pos.Line, pos.Column, e, err))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%d:%d: type %T rendered with go/printer", pos.Line, pos.Column, e))
+			}
+			return false
+		})
+	}
+	return warnings
+}
+
+// exprHandles reports whether expr has a case of its own for e, rather than
+// falling back to printExpr.
+func exprHandles(e ast.Expr) bool {
+	switch e.(type) {
+	case *ast.StarExpr, *ast.Ident, *ast.ArrayType, *ast.MapType, *ast.SelectorExpr,
+		*ast.ChanType, *ast.FuncType, *ast.ParenExpr, *ast.BasicLit, *ast.Ellipsis,
+		*ast.InterfaceType, *ast.StructType, *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+// renderError reports why printExpr cannot render e.
+func renderError(e ast.Expr) error {
+	if _, ok := e.(*ast.BadExpr); ok {
+		return errors.New("invalid expression")
+	}
+	return printer.Fprint(io.Discard, token.NewFileSet(), e)
 }
 
 func fields(fl ast.FieldList) string {
@@ -212,8 +994,167 @@ func fields(fl ast.FieldList) string {
 		for i, n := range f.Names {
 			names[i] = n.Name
 		}
+		if len(names) == 0 {
+			parts = append(parts, expr(f.Type))
+			continue
+		}
 		part := fmt.Sprintf("%s %s", strings.Join(names, ", "), expr(f.Type))
 		parts = append(parts, part)
 	}
 	return strings.Join(parts, ", ")
 }
+
+// countGoAndDefer counts the go and defer statements in the body of fn,
+// including those inside function literals.
+func countGoAndDefer(fn *ast.FuncDecl) (goStmts, defers int) {
+	if fn.Body == nil {
+		return 0, 0
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.GoStmt:
+			goStmts++
+		case *ast.DeferStmt:
+			defers++
+		}
+		return true
+	})
+	return goStmts, defers
+}
+
+func describeParameters(fl *ast.FieldList) []ParameterDescription {
+	if fl == nil {
+		return nil
+	}
+	var params []ParameterDescription
+	for _, f := range fl.List {
+		typ := expr(f.Type)
+		external := isExternalType(f.Type)
+		if len(f.Names) == 0 {
+			params = append(params, ParameterDescription{Type: typ, External: external})
+			continue
+		}
+		for _, n := range f.Names {
+			params = append(params, ParameterDescription{Name: n.Name, Type: typ, External: external})
+		}
+	}
+	return params
+}
+
+func describeTypeParams(fl *ast.FieldList) []TypeParam {
+	if fl == nil {
+		return nil
+	}
+	var typeParams []TypeParam
+	for _, f := range fl.List {
+		constraint := expr(f.Type)
+		for _, n := range f.Names {
+			typeParams = append(typeParams, TypeParam{Name: n.Name, Constraint: constraint})
+		}
+	}
+	return typeParams
+}
+
+func isExternalType(e ast.Expr) bool {
+	external := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if _, ok := n.(*ast.SelectorExpr); ok {
+			external = true
+		}
+		return !external
+	})
+	return external
+}
+
+func callsPanic(fn *ast.FuncDecl) bool {
+	if fn.Body == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "panic" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func returnsError(fn *ast.FuncDecl) bool {
+	if fn.Type.Results == nil {
+		return false
+	}
+	for _, f := range fn.Type.Results.List {
+		if id, ok := f.Type.(*ast.Ident); ok && id.Name == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsContext(fn *ast.FuncDecl) bool {
+	params := fn.Type.Params
+	return params != nil && len(params.List) > 0 && expr(params.List[0].Type) == "context.Context"
+}
+
+func functionSignature(fn *ast.FuncDecl) string {
+	var sb strings.Builder
+	sb.WriteString("func ")
+	if fn.Recv != nil {
+		sb.WriteString("(" + fields(*fn.Recv) + ") ")
+	}
+	sb.WriteString(fn.Name.Name)
+	if fn.Type.TypeParams != nil {
+		sb.WriteString("[" + fields(*fn.Type.TypeParams) + "]")
+	}
+	sb.WriteString(signature(fn.Type))
+	return sb.String()
+}
+
+func signature(ft *ast.FuncType) string {
+	var params string
+	if ft.Params != nil {
+		params = fields(*ft.Params)
+	}
+	sig := fmt.Sprintf("(%s)", params)
+
+	if ft.Results == nil || len(ft.Results.List) == 0 {
+		return sig
+	}
+	results := fields(*ft.Results)
+	if len(ft.Results.List) == 1 && len(ft.Results.List[0].Names) == 0 {
+		return fmt.Sprintf("%s %s", sig, results)
+	}
+	return fmt.Sprintf("%s (%s)", sig, results)
+}
+
+func interfaceType(it *ast.InterfaceType) string {
+	if it.Methods == nil || len(it.Methods.List) == 0 {
+		return "interface{}"
+	}
+	var methods []string
+	for _, m := range it.Methods.List {
+		ft, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			methods = append(methods, expr(m.Type))
+			continue
+		}
+		for _, n := range m.Names {
+			methods = append(methods, n.Name+signature(ft))
+		}
+	}
+	return fmt.Sprintf("interface{ %s }", strings.Join(methods, "; "))
+}
+
+func structType(st *ast.StructType) string {
+	if st.Fields == nil || len(st.Fields.List) == 0 {
+		return "struct{}"
+	}
+	var fieldDecls []string
+	for _, f := range st.Fields.List {
+		fieldDecls = append(fieldDecls, fields(ast.FieldList{List: []*ast.Field{f}}))
+	}
+	return fmt.Sprintf("struct{ %s }", strings.Join(fieldDecls, "; "))
+}
@@ -0,0 +1,1361 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// parseTestSource parses src as the file fileName and fails the test on error.
+func parseTestSource(t *testing.T, fileName, src string, p Param) Func {
+	t.Helper()
+	var funcs Func
+	if err := funcs.ParseSource(fileName, src, p); err != nil {
+		t.Fatalf("ParseSource(%s): %v", fileName, err)
+	}
+	return funcs
+}
+
+// findFunction returns the description named name, failing the test when
+// there is none.
+func findFunction(t *testing.T, descriptions []FunctionDescription, name string) FunctionDescription {
+	t.Helper()
+	for _, desc := range descriptions {
+		if desc.Name == name {
+			return desc
+		}
+	}
+	t.Fatalf("no function named %s in %d descriptions", name, len(descriptions))
+	return FunctionDescription{}
+}
+
+func TestWriteFunctionBodyOnce(t *testing.T) {
+	src := `package p
+
+func Add(a, b int) int {
+	return a + b
+}
+`
+	body := "func Add(a, b int) int {\n\treturn a + b\n}"
+	tests := []struct {
+		name        string
+		includeBody bool
+		wantBodies  int
+	}{
+		{"without body", false, 0},
+		{"with body", true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "add.go", src, Param{IncludeBody: tt.includeBody})
+			text := strings.Join(funcs.FullDescriptions, "")
+			if got := strings.Count(text, body); got != tt.wantBodies {
+				t.Fatalf("body appears %d times, want %d:\n%s", got, tt.wantBodies, text)
+			}
+			if tt.includeBody && !strings.Contains(text, "```go\n"+body+"\n```\n") {
+				t.Errorf("body is not enclosed in a go code fence:\n%s", text)
+			}
+			if doc := funcs.FunctionDescriptions[0].Doc; strings.Contains(doc, body) {
+				t.Errorf("Doc contains the body:\n%s", doc)
+			}
+		})
+	}
+}
+
+// descriptionLine returns the line of doc that starts with prefix, without
+// the prefix.
+func descriptionLine(t *testing.T, doc, prefix string) string {
+	t.Helper()
+	for _, line := range strings.Split(doc, "\n") {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return rest
+		}
+	}
+	t.Fatalf("no %q line in:\n%s", prefix, doc)
+	return ""
+}
+
+func TestDescribeTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		decl       string
+		wantParams string
+		wantReturn string
+	}{
+		{
+			name:       "generic map",
+			decl:       "func Keys[K comparable, V any](m map[K]V) []K",
+			wantParams: "m map[K]V",
+			wantReturn: "[]K",
+		},
+		{
+			name:       "instantiated types",
+			decl:       "func Merge(a List[int], b Map[string, int]) Pair[K, V]",
+			wantParams: "a List[int], b Map[string, int]",
+			wantReturn: "Pair[K, V]",
+		},
+		{
+			name:       "channel directions",
+			decl:       "func worker(jobs <-chan int, done chan<- bool) chan error",
+			wantParams: "jobs <-chan int, done chan<- bool",
+			wantReturn: "chan error",
+		},
+		{
+			name:       "function types",
+			decl:       "func Apply(f func(func() int) func() error, cb func(int) error) func(string) (int, error)",
+			wantParams: "f func(func() int) func() error, cb func(int) error",
+			wantReturn: "func(string) (int, error)",
+		},
+		{
+			name:       "empty interface",
+			decl:       "func Print(v interface{}) struct{}",
+			wantParams: "v interface{}",
+			wantReturn: "struct{}",
+		},
+		{
+			name:       "interface and struct literals",
+			decl:       "func Open(r interface{ Read([]byte) (int, error); io.Closer }) struct{ X, Y int; Name string }",
+			wantParams: "r interface{ Read([]byte) (int, error); io.Closer }",
+			wantReturn: "struct{ X, Y int; Name string }",
+		},
+		{
+			name:       "variadic",
+			decl:       "func Printf(format string, args ...interface{}) (n int, err error)",
+			wantParams: "format string, args ...interface{}",
+			wantReturn: "n int, err error",
+		},
+		{
+			name:       "variadic slices",
+			decl:       "func Join(sep string, parts ...[]byte) []byte",
+			wantParams: "sep string, parts ...[]byte",
+			wantReturn: "[]byte",
+		},
+		{
+			name:       "array lengths and parentheses",
+			decl:       "func f(a [4]byte, b (int), c [N]string) (time.Duration)",
+			wantParams: "a [4]byte, b int, c [N]string",
+			wantReturn: "time.Duration",
+		},
+		{
+			name:       "meaningful parentheses",
+			decl:       "func Ch(c chan (<-chan int), p (*T)) chan<- (chan int)",
+			wantParams: "c chan (<-chan int), p (*T)",
+			wantReturn: "chan<- (chan int)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "types.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{})
+			doc := funcs.FunctionDescriptions[0].Doc
+			if got := descriptionLine(t, doc, "##Parameters: "); got != tt.wantParams {
+				t.Errorf("parameters = %q, want %q", got, tt.wantParams)
+			}
+			if got := descriptionLine(t, doc, "##Return: "); got != tt.wantReturn {
+				t.Errorf("return = %q, want %q", got, tt.wantReturn)
+			}
+		})
+	}
+}
+
+func TestWriteTypeParams(t *testing.T) {
+	tests := []struct {
+		name string
+		decl string
+		want string
+	}{
+		{"generic", "func Map[T, U any](s []T, f func(T) U) []U", "##Type Parameters: T, U any\n"},
+		{"constraints", "func Sum[N int | float64, S ~[]N](s S) N", "##Type Parameters: N int | float64, S ~[]N\n"},
+		{"not generic", "func Len(s []int) int", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "generic.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{})
+			doc := funcs.FunctionDescriptions[0].Doc
+			if tt.want == "" {
+				if strings.Contains(doc, "##Type Parameters") {
+					t.Errorf("unexpected type parameter section:\n%s", doc)
+				}
+				return
+			}
+			if !strings.Contains(doc, tt.want) {
+				t.Errorf("doc does not contain %q:\n%s", tt.want, doc)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var funcs Func
+	err := funcs.ParseSource("broken.go", "package p\n\nfunc Broken( {\n", Param{})
+	if err == nil || !strings.Contains(err.Error(), "broken.go") {
+		t.Errorf("ParseSource error = %v, want a parse error naming broken.go", err)
+	}
+	if len(funcs.FullDescriptions) != 0 {
+		t.Errorf("got %d descriptions for an unparseable file", len(funcs.FullDescriptions))
+	}
+
+	err = funcs.ParseFunctions(context.Background(), Param{FilePath: "does-not-exist.go", FileName: "does-not-exist.go"})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ParseFunctions error = %v, want a not-exist error", err)
+	}
+}
+
+func TestSharedFileSetLines(t *testing.T) {
+	p := Param{Fset: token.NewFileSet()}
+	var funcs Func
+	for _, file := range []struct{ name, src string }{
+		{"a.go", "package p\n\nfunc A() {}\n\nfunc B() {}\n"},
+		{"b.go", "package p\n\nimport \"fmt\"\n\nfunc C() {}\n\nfunc D() {\n\tfmt.Println()\n}\n\nfunc E() {}\n"},
+	} {
+		if err := funcs.ParseSource(file.name, file.src, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]int{"A": 3, "B": 5, "C": 5, "D": 7, "E": 11}
+	for name, line := range want {
+		if got := findFunction(t, funcs.FunctionDescriptions, name).StartLine; got != line {
+			t.Errorf("%s starts on line %d, want %d", name, got, line)
+		}
+	}
+}
+
+func TestFunctionPositions(t *testing.T) {
+	src := `package p
+
+func First() {
+	println("first")
+}
+
+
+	func Second(a int) int {
+		return a
+	}
+`
+	funcs := parseTestSource(t, "pos.go", src, Param{})
+	tests := []struct {
+		name                         string
+		startLine, endLine, startCol int
+	}{
+		{"First", 3, 5, 1},
+		{"Second", 8, 10, 2},
+	}
+	for _, tt := range tests {
+		desc := findFunction(t, funcs.FunctionDescriptions, tt.name)
+		if desc.StartLine != tt.startLine || desc.EndLine != tt.endLine || desc.StartCol != tt.startCol {
+			t.Errorf("%s at %d-%d col %d, want %d-%d col %d",
+				tt.name, desc.StartLine, desc.EndLine, desc.StartCol, tt.startLine, tt.endLine, tt.startCol)
+		}
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	src := `package p
+
+type Server struct{}
+
+func (s *Server) Handle() {}
+
+func (Server) Name() string { return "" }
+
+type Map[K comparable, V any] struct{}
+
+func (m *Map[K, V]) Get(k K) (v V) { return }
+
+func Plain() {}
+`
+	funcs := parseTestSource(t, "server.go", src, Param{})
+	tests := []struct {
+		name         string
+		wantReceiver string
+		wantType     string
+		wantMethod   bool
+	}{
+		{"Handle", "s *Server", "Server", true},
+		{"Name", "Server", "Server", true},
+		{"Get", "m *Map[K, V]", "Map", true},
+		{"Plain", "", "", false},
+	}
+	for _, tt := range tests {
+		desc := findFunction(t, funcs.FunctionDescriptions, tt.name)
+		if desc.Receiver != tt.wantReceiver || desc.ReceiverType != tt.wantType || desc.IsMethod != tt.wantMethod {
+			t.Errorf("%s: receiver %q, receiver_type %q, is_method %t; want %q, %q, %t",
+				tt.name, desc.Receiver, desc.ReceiverType, desc.IsMethod, tt.wantReceiver, tt.wantType, tt.wantMethod)
+		}
+	}
+}
+
+func TestFunctionKind(t *testing.T) {
+	src := `package p
+
+import "testing"
+
+func TestParse(t *testing.T) {}
+
+func Test(t *testing.T) {}
+
+func Testify(t *testing.T) {}
+
+func BenchmarkParse(b *testing.B) {}
+
+func ExampleParse() {}
+
+func Example_second() {}
+
+func FuzzParse(f *testing.F) {}
+
+func TestWrongParam(b *testing.B) {}
+
+func helper(t *testing.T) {}
+
+func (s *suite) TestMethod(t *testing.T) {}
+`
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"TestParse", kindTest},
+		{"Test", kindTest},
+		{"Testify", kindRegular},
+		{"BenchmarkParse", kindBenchmark},
+		{"ExampleParse", kindExample},
+		{"Example_second", kindExample},
+		{"FuzzParse", kindFuzz},
+		{"TestWrongParam", kindRegular},
+		{"helper", kindRegular},
+		{"TestMethod", kindRegular},
+	}
+	funcs := parseTestSource(t, "parse_test.go", src, Param{})
+	for _, tt := range tests {
+		if got := findFunction(t, funcs.TestFunctionDescriptions, tt.name).Kind; got != tt.want {
+			t.Errorf("%s: kind %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	funcs = parseTestSource(t, "parse.go", "package p\n\nimport \"testing\"\n\nfunc TestParse(t *testing.T) {}\n", Param{})
+	if got := findFunction(t, funcs.FunctionDescriptions, "TestParse").Kind; got != kindRegular {
+		t.Errorf("TestParse outside a test file: kind %q, want %q", got, kindRegular)
+	}
+}
+
+func TestTestFileDetection(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     bool
+	}{
+		{"foo_test.go", true},
+		{"foo_tester.go", false},
+		{"my_test_helpers.go", false},
+		{"test.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			funcs := parseTestSource(t, tt.fileName, "package p\n\nfunc F() {}\n", Param{})
+			if got := len(funcs.TestFunctionDescriptions) == 1; got != tt.want {
+				t.Errorf("F treated as a test function: %t, want %t", got, tt.want)
+			}
+			if got := strings.Contains(funcs.FullDescriptions[0], "go test file"); got != tt.want {
+				t.Errorf("described as a test file: %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileImports(t *testing.T) {
+	src := `package p
+
+import (
+	"fmt"
+	str "strings"
+	_ "embed"
+	. "math"
+)
+
+func F() { fmt.Println(str.ToUpper("x"), Pi) }
+`
+	funcs := parseTestSource(t, "imports.go", src, Param{})
+	want := []string{"fmt", "str strings", "_ embed", ". math"}
+	if got := funcs.FileDescriptions[0].Imports; !reflect.DeepEqual(got, want) {
+		t.Errorf("imports = %q, want %q", got, want)
+	}
+	if section := "##Imports\n  fmt\n  str strings\n  _ embed\n  . math\n"; !strings.Contains(funcs.FullDescriptions[0], section) {
+		t.Errorf("description does not contain %q:\n%s", section, funcs.FullDescriptions[0])
+	}
+
+	funcs = parseTestSource(t, "none.go", "package p\n", Param{})
+	if got := funcs.FileDescriptions[0].Imports; len(got) != 0 {
+		t.Errorf("imports = %q, want none", got)
+	}
+	if strings.Contains(funcs.FullDescriptions[0], "##Imports") {
+		t.Errorf("unexpected imports section:\n%s", funcs.FullDescriptions[0])
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	src := "package mem\n\n// Hello greets.\nfunc Hello() string { return \"hi\" }\n\nfunc (g *Greeter) Greet() {}\n"
+	funcs := parseTestSource(t, "mem.go", src, Param{})
+	if len(funcs.FunctionDescriptions) != 2 {
+		t.Fatalf("got %d functions, want 2", len(funcs.FunctionDescriptions))
+	}
+	hello := findFunction(t, funcs.FunctionDescriptions, "Hello")
+	if hello.Package != "mem" || hello.FilePath != "mem.go" || !strings.HasPrefix(hello.Doc, "Hello greets.\n") {
+		t.Errorf("Hello = %+v", hello)
+	}
+	findFunction(t, funcs.FunctionDescriptions, "Greet")
+}
+
+func TestExportedOnly(t *testing.T) {
+	src := `package p
+
+type Exported struct{}
+type unexported struct{}
+
+func init() {}
+func Public() {}
+func private() {}
+func (Exported) Method() {}
+func (Exported) method() {}
+func (*unexported) Method() {}
+`
+	testSrc := `package p
+
+import "testing"
+
+func TestPublic(t *testing.T) {}
+func helper() {}
+`
+	tests := []struct {
+		exportedOnly bool
+		want         []string
+		wantTests    []string
+	}{
+		{false, []string{"init", "Public", "private", "Method", "method", "Method"}, []string{"TestPublic", "helper"}},
+		{true, []string{"Public", "Method"}, []string{"TestPublic"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("exported-only=%t", tt.exportedOnly), func(t *testing.T) {
+			p := Param{ExportedOnly: tt.exportedOnly}
+			funcs := parseTestSource(t, "p.go", src, p)
+			funcs.Merge(parseTestSource(t, "p_test.go", testSrc, p))
+			if got := functionNames(funcs.FunctionDescriptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+			if got := functionNames(funcs.TestFunctionDescriptions); !reflect.DeepEqual(got, tt.wantTests) {
+				t.Errorf("test functions = %q, want %q", got, tt.wantTests)
+			}
+		})
+	}
+}
+
+func functionNames(descriptions []FunctionDescription) []string {
+	var names []string
+	for _, desc := range descriptions {
+		names = append(names, desc.Name)
+	}
+	return names
+}
+
+func TestFunctionSignature(t *testing.T) {
+	tests := []struct {
+		decl string
+		want string
+	}{
+		{"func (s *Server) Handle(w http.ResponseWriter, r *http.Request) (int, error)", "func (s *Server) Handle(w http.ResponseWriter, r *http.Request) (int, error)"},
+		{"func Parse(s string) error", "func Parse(s string) error"},
+		{"func Split(s, sep string) (head, tail string)", "func Split(s, sep string) (head, tail string)"},
+		{"func Map[T, U any](s []T, f func(T) U) []U", "func Map[T, U any](s []T, f func(T) U) []U"},
+		{"func (l *List[T]) Push(v T)", "func (l *List[T]) Push(v T)"},
+		{"func Run()", "func Run()"},
+	}
+	for _, tt := range tests {
+		funcs := parseTestSource(t, "sig.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{})
+		if got := funcs.FunctionDescriptions[0].Signature; got != tt.want {
+			t.Errorf("signature = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFunctionCalls(t *testing.T) {
+	src := `package p
+
+import (
+	"fmt"
+	"os"
+)
+
+func Run(s *Server) {
+	f, _ := os.Open("x")
+	fmt.Println(f)
+	s.Start()
+	fmt.Println("again")
+	func() { helper() }()
+}
+`
+	funcs := parseTestSource(t, "calls.go", src, Param{})
+	want := []string{"os.Open", "fmt.Println", "s.Start", "helper"}
+	if got := funcs.FunctionDescriptions[0].Calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+}
+
+func TestMultiByteSource(t *testing.T) {
+	src := `package p
+
+import "fmt"
+
+var greeting = "héllo, 世界 🌍"
+
+func Greet() {
+	fmt.Println(greeting, "ünïcödé")
+}
+`
+	p := Param{Fset: token.NewFileSet()}
+	// A file parsed earlier moves the base of the file set.
+	parseTestSource(t, "first.go", "package p\n\nvar x = \"日本語\"\n", p)
+	funcs := parseTestSource(t, "greet.go", src, p)
+
+	desc := funcs.FunctionDescriptions[0]
+	if want := []string{"fmt.Println"}; !reflect.DeepEqual(desc.Calls, want) {
+		t.Errorf("calls = %q, want %q", desc.Calls, want)
+	}
+	if call := "  fmt.Println(greeting, \"ünïcödé\")\n"; !strings.Contains(desc.Doc, call) {
+		t.Errorf("doc does not contain %q:\n%s", call, desc.Doc)
+	}
+}
+
+func TestSourceTextFallback(t *testing.T) {
+	fset := token.NewFileSet()
+	first, err := parseCode(fset, "first.go", "package p\n\nfunc Short() {}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := parseCode(fset, "second.go", "package p\n\nfunc Longer(a, b int) int { return a + b }\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A node from another file does not map onto the code of this one, so it
+	// is printed from the AST rather than sliced.
+	src := source{fset: fset, file: fset.File(first.Pos()), code: "package p\n\nfunc Short() {}\n"}
+	fn := second.Decls[0].(*ast.FuncDecl)
+	if got, want := src.text(fn.Type), "func(a, b int) int"; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+	if got, want := src.text(first.Decls[0]), "func Short() {}"; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+}
+
+func TestExprMatchesPrinter(t *testing.T) {
+	tests := []string{
+		"int",
+		"*pkg.Type",
+		"[]map[string][4]byte",
+		"List[T]",
+		"Map[K, V]",
+		"<-chan int",
+		"chan<- []string",
+		"chan func() error",
+		"func(func() int) func() error",
+		"func(a, b int) (n int, err error)",
+		"[N + 1]int",
+		"[len(x)]byte",
+		"~int | ~string",
+		"chan (<-chan int)",
+	}
+	for _, typ := range tests {
+		e, err := parser.ParseExpr(typ)
+		if err != nil {
+			t.Fatalf("ParseExpr(%q): %v", typ, err)
+		}
+		if got := expr(e); got != typ {
+			t.Errorf("expr(%q) = %q", typ, got)
+		}
+		if got := printExpr(e); got != typ {
+			t.Errorf("printExpr(%q) = %q", typ, got)
+		}
+	}
+}
+
+func TestComplexity(t *testing.T) {
+	src := `package p
+
+func Trivial() int { return 1 }
+
+func Branchy(xs []int, m map[string]int, ch chan int) int {
+	n := 0
+	for _, x := range xs {
+		if x > 0 && x < 10 || x == 100 {
+			n++
+		}
+	}
+	for i := 0; i < 3; i++ {
+		switch i {
+		case 0, 1:
+			n++
+		case 2:
+		default:
+		}
+	}
+	select {
+	case v := <-ch:
+		n += v
+	default:
+	}
+	return n
+}
+`
+	funcs := parseTestSource(t, "complexity.go", src, Param{})
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"Trivial", 1},
+		// 1 + range + if + && + || + for + two cases + one comm clause
+		{"Branchy", 9},
+	}
+	for _, tt := range tests {
+		if got := findFunction(t, funcs.FunctionDescriptions, tt.name).Complexity; got != tt.want {
+			t.Errorf("%s: complexity %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLineCount(t *testing.T) {
+	src := `package p
+
+func OneLine() {}
+
+func Multi(
+	a int,
+) int {
+
+	return a
+}
+`
+	funcs := parseTestSource(t, "lines.go", src, Param{})
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"OneLine", 1},
+		{"Multi", 6},
+	}
+	for _, tt := range tests {
+		if got := findFunction(t, funcs.FunctionDescriptions, tt.name).LineCount; got != tt.want {
+			t.Errorf("%s: line count %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecation(t *testing.T) {
+	src := `package p
+
+// Old does it the old way.
+//
+// Deprecated: Use New instead,
+// which is faster.
+//
+// More text.
+func Old() {}
+
+// Gone is gone.
+// Deprecated:
+func Gone() {}
+
+// New mentions that nothing here is Deprecated: at all.
+func New() {}
+
+func Undocumented() {}
+`
+	funcs := parseTestSource(t, "dep.go", src, Param{})
+	tests := []struct {
+		name       string
+		deprecated bool
+		note       string
+	}{
+		{"Old", true, "Use New instead, which is faster."},
+		{"Gone", true, ""},
+		{"New", false, ""},
+		{"Undocumented", false, ""},
+	}
+	for _, tt := range tests {
+		desc := findFunction(t, funcs.FunctionDescriptions, tt.name)
+		if desc.Deprecated != tt.deprecated || desc.DeprecationNote != tt.note {
+			t.Errorf("%s: deprecated %t, note %q; want %t, %q", tt.name, desc.Deprecated, desc.DeprecationNote, tt.deprecated, tt.note)
+		}
+	}
+}
+
+func TestUndocumentedOnly(t *testing.T) {
+	src := `package p
+
+// Documented says what it does.
+func Documented() {}
+
+//
+func Blank() {}
+
+func Undocumented() {}
+`
+	tests := []struct {
+		undocumentedOnly bool
+		want             []string
+	}{
+		{false, []string{"Documented", "Blank", "Undocumented"}},
+		{true, []string{"Blank", "Undocumented"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("undocumented-only=%t", tt.undocumentedOnly), func(t *testing.T) {
+			funcs := parseTestSource(t, "doc.go", src, Param{UndocumentedOnly: tt.undocumentedOnly})
+			if got := functionNames(funcs.FunctionDescriptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+			for _, desc := range funcs.FunctionDescriptions {
+				if want := desc.Name == "Documented"; desc.HasDoc != want {
+					t.Errorf("%s: HasDoc = %t, want %t", desc.Name, desc.HasDoc, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupMethods(t *testing.T) {
+	src := `package p
+
+type Server struct{}
+type List[T any] []T
+
+func (s *Server) Start() {}
+
+func Helper() {}
+
+func (l *List[T]) Push(v T) {}
+
+func (Server) Stop() {}
+`
+	tests := []struct {
+		groupMethods bool
+		want         []string
+	}{
+		{false, []string{"Start", "Helper", "Push", "Stop"}},
+		{true, []string{"###Functions", "Helper", "###Methods of type Server", "Start", "Stop", "###Methods of type List", "Push"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("group-methods=%t", tt.groupMethods), func(t *testing.T) {
+			text := parseTestSource(t, "server.go", src, Param{GroupMethods: tt.groupMethods}).FullDescriptions[0]
+			var got []string
+			for _, line := range strings.Split(text, "\n") {
+				switch {
+				case strings.HasPrefix(line, "###Functions"), strings.HasPrefix(line, "###Methods of type "):
+					got = append(got, line)
+				case strings.HasPrefix(line, "##Function name: "):
+					got = append(got, strings.TrimPrefix(line, "##Function name: "))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("outline = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosures(t *testing.T) {
+	src := `package p
+
+func Serve(handlers []func()) {
+	done := func(err error) bool { return err == nil }
+	go func() {
+		for _, h := range handlers {
+			h()
+		}
+	}()
+	_ = done
+}
+
+func Plain() {}
+`
+	tests := []struct {
+		includeBody bool
+		want        []ClosureDescription
+	}{
+		{false, nil},
+		{true, []ClosureDescription{
+			{Signature: "func(err error) bool", StartLine: 4, EndLine: 4, StartCol: 10},
+			{Signature: "func()", StartLine: 5, EndLine: 9, StartCol: 5},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("include-body=%t", tt.includeBody), func(t *testing.T) {
+			funcs := parseTestSource(t, "serve.go", src, Param{IncludeBody: tt.includeBody})
+			if got := findFunction(t, funcs.FunctionDescriptions, "Serve").Closures; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closures = %+v, want %+v", got, tt.want)
+			}
+			if got := findFunction(t, funcs.FunctionDescriptions, "Plain").Closures; got != nil {
+				t.Errorf("Plain closures = %+v, want none", got)
+			}
+		})
+	}
+}
+
+func TestResults(t *testing.T) {
+	tests := []struct {
+		name string
+		decl string
+		want string
+	}{
+		{"unnamed", "func F() error", "error"},
+		{"multiple unnamed", "func F() (int, error)", "int, error"},
+		{"named", "func F() (n int, err error)", "n int, err error"},
+		{"grouped names", "func F() (x, y int)", "x, y int"},
+		{"unnamed func type", "func F() func() error", "func() error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "results.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{})
+			if got := descriptionLine(t, funcs.FullDescriptions[0], "##Return: "); got != tt.want {
+				t.Errorf("return = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		decl        string
+		wantParams  string
+		wantResults string
+	}{
+		{"func f(a, b int, c string) (x, y int)", "a, b int, c string", "x, y int"},
+		{"func f(int, string) (bool, error)", "int, string", "bool, error"},
+		{"func f(a int, rest ...string) (ok bool)", "a int, rest ...string", "ok bool"},
+		{"func f(_ int, _, b string) (_ error)", "_ int, _, b string", "_ error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.decl, func(t *testing.T) {
+			doc := parseTestSource(t, "fields.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{}).FullDescriptions[0]
+			if got := descriptionLine(t, doc, "##Parameters: "); got != tt.wantParams {
+				t.Errorf("params = %q, want %q", got, tt.wantParams)
+			}
+			if got := descriptionLine(t, doc, "##Return: "); got != tt.wantResults {
+				t.Errorf("results = %q, want %q", got, tt.wantResults)
+			}
+		})
+	}
+}
+
+func TestReferencedTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		decl string
+		want []string
+	}{
+		{"signature", "func F(r io.Reader, opts Options) (*Result, error)", []string{"io.Reader", "Options", "Result"}},
+		{"predeclared only", "func F(n int, s string) bool", nil},
+		{"function type params", "func Map[T, U any](s []T, f func(T) U) []U", nil},
+		{"receiver type param", "func (l *List[T]) Push(v T)", []string{"List"}},
+		{"receiver type params", "func (m Map[K, V]) Get(k K) (V, bool)", []string{"Map"}},
+		{"receiver type param and named type", "func (l *List[T]) Each(f func(T) Item)", []string{"List", "Item"}},
+		{"blank receiver type param", "func (l *List[_]) Len() Size", []string{"List", "Size"}},
+		{"body", "func F() { var b bytes.Buffer; _ = Config{}; _ = make(map[string]Entry); _ = new(Node) }", []string{"bytes.Buffer", "Config", "Entry", "Node"}},
+		{"type switch", "func F(v any) { switch v.(type) { case Circle, *Square: } }", []string{"Circle", "Square"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "refs.go", "package p\n\n"+tt.decl+"\n", Param{})
+			if got := funcs.FunctionDescriptions[0].ReferencedTypes; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("referenced types = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExported(t *testing.T) {
+	src := `package p
+
+type T struct{}
+type t2 struct{}
+
+func Foo() {}
+func foo() {}
+func (t *T) Bar() {}
+func (t *T) bar() {}
+func (t *t2) Baz() {}
+func (l List[E]) Len() int { return 0 }
+`
+	testSrc := `package p
+
+import "testing"
+
+func TestFoo(t *testing.T) {}
+func helper() {}
+`
+	funcs := parseTestSource(t, "p.go", src, Param{})
+	funcs.Merge(parseTestSource(t, "p_test.go", testSrc, Param{}))
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Foo", true},
+		{"foo", false},
+		{"Bar", true},
+		{"bar", false},
+		{"Baz", false},
+		{"Len", true},
+		{"TestFoo", true},
+		{"helper", false},
+	}
+	all := append(funcs.FunctionDescriptions, funcs.TestFunctionDescriptions...)
+	for _, tt := range tests {
+		if got := findFunction(t, all, tt.name).Exported; got != tt.want {
+			t.Errorf("%s: exported %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTypeWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		decl string
+		want []string
+	}{
+		{"handled types", "func F(a []int, m map[string]*T, f func(...any)) (<-chan int, error)", nil},
+		{"generic types", "func F[K comparable, V any](m Map[K, V], l List[K]) V", nil},
+		{"array length expression", "func F(a [N + 1]int)", []string{"3:11: type *ast.BinaryExpr rendered with go/printer"}},
+		{"union constraint", "func Sum[T ~int | ~float64](v ...T) T", []string{"3:12: type *ast.BinaryExpr rendered with go/printer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "warn.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{})
+			if got := funcs.FunctionDescriptions[0].Warnings; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("warnings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeWarningsUnrenderable(t *testing.T) {
+	code := "package p\n\nfunc F(a int) {}\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "bad.go", code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := file.Decls[0].(*ast.FuncDecl)
+	param := fn.Type.Params.List[0]
+	param.Type = &ast.BadExpr{From: param.Type.Pos(), To: param.Type.End()}
+
+	src := source{fset: fset, file: fset.File(file.Pos()), code: code}
+	want := []string{"3:10: unable to render type *ast.BadExpr: invalid expression"}
+	if got := typeWarnings(fn, src); !reflect.DeepEqual(got, want) {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+}
+
+func TestMinComplexity(t *testing.T) {
+	src := `package p
+
+func Simple() {}
+
+func Branchy(a, b int) int {
+	if a > b {
+		return a
+	}
+	for i := 0; i < b; i++ {
+		if i == a || i == b {
+			continue
+		}
+	}
+	return b
+}
+`
+	tests := []struct {
+		minComplexity int
+		want          []string
+	}{
+		{0, []string{"Simple", "Branchy"}},
+		{3, []string{"Branchy"}},
+		{5, []string{"Branchy"}},
+		{6, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("min-complexity=%d", tt.minComplexity), func(t *testing.T) {
+			funcs := parseTestSource(t, "c.go", src, Param{MinComplexity: tt.minComplexity})
+			if got := functionNames(funcs.FunctionDescriptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+			for _, name := range []string{"Simple", "Branchy"} {
+				if got := strings.Contains(funcs.FullDescriptions[0], "##Function name: "+name+"\n"); got != slices.Contains(tt.want, name) {
+					t.Errorf("description of %s included: %t", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputPath(t *testing.T) {
+	root := filepath.Join("home", "me", "project")
+	tests := []struct {
+		name string
+		p    Param
+		want string
+	}{
+		{"no root", Param{FilePath: filepath.Join("a", "b.go")}, "a/b.go"},
+		{"top level", Param{FilePath: filepath.Join(root, "main.go"), Root: root}, "main.go"},
+		{"nested", Param{FilePath: filepath.Join(root, "internal", "store", "store.go"), Root: root}, "internal/store/store.go"},
+		{"file root", Param{FilePath: filepath.Join(root, "main.go"), Root: filepath.Join(root, "main.go")}, "main.go"},
+		{"current directory", Param{FilePath: filepath.Join("cmd", "main.go"), Root: "."}, "cmd/main.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.outputPath(); got != tt.want {
+				t.Errorf("outputPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReturnsError(t *testing.T) {
+	tests := []struct {
+		decl string
+		want bool
+	}{
+		{"func F() (T, error)", true},
+		{"func F() T", false},
+		{"func F() error", true},
+		{"func F() (n int, err error)", true},
+		{"func F()", false},
+		{"func F() func() error", false},
+		{"func F() *errors.Error", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.decl, func(t *testing.T) {
+			funcs := parseTestSource(t, "err.go", "package p\n\n"+tt.decl+" { panic(0) }\n", Param{})
+			if got := funcs.FunctionDescriptions[0].ReturnsError; got != tt.want {
+				t.Errorf("returns error %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcceptsContext(t *testing.T) {
+	tests := []struct {
+		decl string
+		want bool
+	}{
+		{"func F(ctx context.Context, id string)", true},
+		{"func F(context.Context)", true},
+		{"func (s *S) F(ctx context.Context)", true},
+		{"func F(id string, ctx context.Context)", false},
+		{"func F(ctx *context.Context)", false},
+		{"func F()", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.decl, func(t *testing.T) {
+			funcs := parseTestSource(t, "ctx.go", "package p\n\n"+tt.decl+" {}\n", Param{})
+			if got := funcs.FunctionDescriptions[0].AcceptsContext; got != tt.want {
+				t.Errorf("accepts context %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoAndDeferCounts(t *testing.T) {
+	src := `package p
+
+func Spawn(ch chan int) {
+	defer close(ch)
+	go work(ch)
+	go func() {
+		defer recover()
+		ch <- 1
+	}()
+}
+
+func Plain() {}
+
+func work(ch chan int) {}
+`
+	funcs := parseTestSource(t, "spawn.go", src, Param{})
+	tests := []struct {
+		name       string
+		wantGo     int
+		wantDefers int
+	}{
+		{"Spawn", 2, 2},
+		{"Plain", 0, 0},
+	}
+	for _, tt := range tests {
+		desc := findFunction(t, funcs.FunctionDescriptions, tt.name)
+		if desc.GoStatements != tt.wantGo || desc.Defers != tt.wantDefers {
+			t.Errorf("%s: %d go statements, %d defers; want %d, %d", tt.name, desc.GoStatements, desc.Defers, tt.wantGo, tt.wantDefers)
+		}
+	}
+}
+
+func TestCallsPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"panics", `panic("boom")`, true},
+		{"nested", `if true { for { panic(errors.New("x")) } }`, true},
+		{"in closure", `defer func() { panic(1) }()`, true},
+		{"no panic", `println("fine")`, false},
+		{"recover only", `defer func() { recover() }()`, false},
+		{"method named panic", `log.panic("x")`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "panic.go", "package p\n\nfunc F() {\n\t"+tt.body+"\n}\n", Param{})
+			if got := funcs.FunctionDescriptions[0].CallsPanic; got != tt.want {
+				t.Errorf("calls panic %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParameterDescriptions(t *testing.T) {
+	src := `package p
+
+func Copy(dst *Buffer, src io.Reader, opts ...Option) (n int64, err error) { return }
+
+func Load(m map[string]*http.Request, ids []ID) (Result, *os.File) { return }
+
+func None() {}
+`
+	funcs := parseTestSource(t, "params.go", src, Param{})
+	tests := []struct {
+		name        string
+		wantParams  []ParameterDescription
+		wantResults []ParameterDescription
+	}{
+		{
+			"Copy",
+			[]ParameterDescription{
+				{Name: "dst", Type: "*Buffer"},
+				{Name: "src", Type: "io.Reader", External: true},
+				{Name: "opts", Type: "...Option"},
+			},
+			[]ParameterDescription{{Name: "n", Type: "int64"}, {Name: "err", Type: "error"}},
+		},
+		{
+			"Load",
+			[]ParameterDescription{
+				{Name: "m", Type: "map[string]*http.Request", External: true},
+				{Name: "ids", Type: "[]ID"},
+			},
+			[]ParameterDescription{{Type: "Result"}, {Type: "*os.File", External: true}},
+		},
+		{"None", nil, nil},
+	}
+	for _, tt := range tests {
+		desc := findFunction(t, funcs.FunctionDescriptions, tt.name)
+		if !reflect.DeepEqual(desc.Parameters, tt.wantParams) {
+			t.Errorf("%s: parameters = %+v, want %+v", tt.name, desc.Parameters, tt.wantParams)
+		}
+		if !reflect.DeepEqual(desc.Results, tt.wantResults) {
+			t.Errorf("%s: results = %+v, want %+v", tt.name, desc.Results, tt.wantResults)
+		}
+	}
+}
+
+func TestExcludeFuncs(t *testing.T) {
+	src := `package p
+
+func mockStore() {}
+func mockClock() {}
+func NewStore() {}
+func (s *Store) mockReset() {}
+func unmocked() {}
+`
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"", []string{"mockStore", "mockClock", "NewStore", "mockReset", "unmocked"}},
+		{"^mock", []string{"NewStore", "unmocked"}},
+		{"Store$", []string{"mockClock", "mockReset", "unmocked"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			var p Param
+			if tt.pattern != "" {
+				p.ExcludeFuncs = regexp.MustCompile(tt.pattern)
+			}
+			funcs := parseTestSource(t, "mock.go", src, p)
+			if got := functionNames(funcs.FunctionDescriptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+			if tt.pattern != "" && strings.Contains(funcs.FullDescriptions[0], "##Function name: mockStore") {
+				t.Error("excluded function is still described")
+			}
+		})
+	}
+}
+
+func TestIncludeFuncs(t *testing.T) {
+	src := `package p
+
+func HandleGet() {}
+func HandlePost() {}
+func HandleDebug() {}
+func (s *Server) HandleStop() {}
+func serve() {}
+func UnHandle() {}
+`
+	tests := []struct {
+		name    string
+		include string
+		exclude string
+		want    []string
+	}{
+		{"none", "", "", []string{"HandleGet", "HandlePost", "HandleDebug", "HandleStop", "serve", "UnHandle"}},
+		{"include", "^Handle", "", []string{"HandleGet", "HandlePost", "HandleDebug", "HandleStop"}},
+		{"exclude wins", "^Handle", "Debug$", []string{"HandleGet", "HandlePost", "HandleStop"}},
+		{"no match", "^Serve", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Param
+			if tt.include != "" {
+				p.IncludeFuncs = regexp.MustCompile(tt.include)
+			}
+			if tt.exclude != "" {
+				p.ExcludeFuncs = regexp.MustCompile(tt.exclude)
+			}
+			funcs := parseTestSource(t, "handlers.go", src, p)
+			if got := functionNames(funcs.FunctionDescriptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamAndResultCounts(t *testing.T) {
+	tests := []struct {
+		decl    string
+		params  int
+		results int
+	}{
+		{"func f(a, b int, c string) (int, error) { return 0, nil }", 3, 2},
+		{"func f() {}", 0, 0},
+		{"func f(int, string) {}", 2, 0},
+		{"func f(args ...string) (n int, err error) { return }", 1, 2},
+		{"func f(a, b, c int) (x, y float64) { return }", 3, 2},
+		{"func (s *S) f(_ int) bool { return false }", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.decl, func(t *testing.T) {
+			funcs := parseTestSource(t, "f.go", "package p\n\n"+tt.decl+"\n", Param{})
+			if len(funcs.FunctionDescriptions) != 1 {
+				t.Fatalf("got %d functions, want 1", len(funcs.FunctionDescriptions))
+			}
+			desc := funcs.FunctionDescriptions[0]
+			if desc.ParamCount != tt.params || desc.ResultCount != tt.results {
+				t.Errorf("counts = %d params, %d results, want %d, %d", desc.ParamCount, desc.ResultCount, tt.params, tt.results)
+			}
+		})
+	}
+}
+
+func TestMarkVisibility(t *testing.T) {
+	src := `package p
+
+// Open opens.
+func Open() {}
+func close() {}
+func (s *Store) Get() {}
+func (s *store) Put() {}
+`
+	tests := []struct {
+		mark bool
+		want []string
+	}{
+		{false, []string{"Open", "close", "Get", "Put"}},
+		{true, []string{"[exported] Open", "[unexported] close", "[exported] Get", "[unexported] Put"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.mark), func(t *testing.T) {
+			funcs := parseTestSource(t, "p.go", src, Param{MarkVisibility: tt.mark})
+			var got []string
+			for _, line := range strings.Split(funcs.FullDescriptions[0], "\n") {
+				if name, ok := strings.CutPrefix(line, "##Function name: "); ok {
+					got = append(got, name)
+				} else if strings.Contains(line, "exported]") {
+					t.Errorf("marker outside a function heading: %q", line)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("headings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocParagraphs(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"none", "", ""},
+		{"line", "// Run runs.\n", "Run runs.\n"},
+		{
+			"two paragraphs",
+			"// Run runs the server.\n//\n// It blocks until ctx is done.\n// Errors are logged.\n",
+			"Run runs the server.\n\nIt blocks until ctx is done.\nErrors are logged.\n",
+		},
+		{
+			"list",
+			"// Run runs:\n//\n//   - first\n//   - second\n",
+			"Run runs:\n\n  - first\n  - second\n",
+		},
+		{
+			"block",
+			"/*\nRun runs.\n\nIt blocks.\n*/\n",
+			"Run runs.\n\nIt blocks.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs := parseTestSource(t, "run.go", "package p\n\n"+tt.doc+"func Run() {}\n", Param{})
+			desc := funcs.FunctionDescriptions[0]
+			if !strings.HasPrefix(desc.Doc, tt.want+"##Function name: Run\n") {
+				t.Errorf("doc = %q, want prefix %q", desc.Doc, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeParams(t *testing.T) {
+	tests := []struct {
+		decl string
+		want []TypeParam
+	}{
+		{"func F() {}", nil},
+		{"func F[T constraints.Ordered, U any]() {}", []TypeParam{{"T", "constraints.Ordered"}, {"U", "any"}}},
+		{"func F[K comparable, V any](m map[K]V) {}", []TypeParam{{"K", "comparable"}, {"V", "any"}}},
+		{"func F[A, B any]() {}", []TypeParam{{"A", "any"}, {"B", "any"}}},
+		{"func F[S ~[]E, E int | string]() {}", []TypeParam{{"S", "~[]E"}, {"E", "int | string"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.decl, func(t *testing.T) {
+			funcs := parseTestSource(t, "f.go", "package p\n\n"+tt.decl+"\n", Param{})
+			if got := funcs.FunctionDescriptions[0].TypeParams; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("type params = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,830 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: functions.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FunctionDescriptions is the message written to functions.pb by
+// --format protobuf. Field numbers must never be reused.
+type FunctionDescriptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemaVersion uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	GeneratedAt   string                 `protobuf:"bytes,2,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	Functions     []*FunctionDescription `protobuf:"bytes,3,rep,name=functions,proto3" json:"functions,omitempty"`
+	TestFunctions []*FunctionDescription `protobuf:"bytes,4,rep,name=test_functions,json=testFunctions,proto3" json:"test_functions,omitempty"`
+}
+
+func (x *FunctionDescriptions) Reset() {
+	*x = FunctionDescriptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_functions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FunctionDescriptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionDescriptions) ProtoMessage() {}
+
+func (x *FunctionDescriptions) ProtoReflect() protoreflect.Message {
+	mi := &file_functions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionDescriptions.ProtoReflect.Descriptor instead.
+func (*FunctionDescriptions) Descriptor() ([]byte, []int) {
+	return file_functions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FunctionDescriptions) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *FunctionDescriptions) GetGeneratedAt() string {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return ""
+}
+
+func (x *FunctionDescriptions) GetFunctions() []*FunctionDescription {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *FunctionDescriptions) GetTestFunctions() []*FunctionDescription {
+	if x != nil {
+		return x.TestFunctions
+	}
+	return nil
+}
+
+type FunctionDescription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc             string                  `protobuf:"bytes,2,opt,name=doc,proto3" json:"doc,omitempty"`
+	Signature       string                  `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Package         string                  `protobuf:"bytes,4,opt,name=package,proto3" json:"package,omitempty"`
+	FilePath        string                  `protobuf:"bytes,5,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	IsTestFunction  bool                    `protobuf:"varint,6,opt,name=is_test_function,json=isTestFunction,proto3" json:"is_test_function,omitempty"`
+	Kind            string                  `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`
+	Receiver        string                  `protobuf:"bytes,8,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	IsMethod        bool                    `protobuf:"varint,9,opt,name=is_method,json=isMethod,proto3" json:"is_method,omitempty"`
+	StartLine       int64                   `protobuf:"varint,10,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	EndLine         int64                   `protobuf:"varint,11,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	StartCol        int64                   `protobuf:"varint,12,opt,name=start_col,json=startCol,proto3" json:"start_col,omitempty"`
+	Calls           []string                `protobuf:"bytes,13,rep,name=calls,proto3" json:"calls,omitempty"`
+	Complexity      int64                   `protobuf:"varint,14,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	LineCount       int64                   `protobuf:"varint,15,opt,name=line_count,json=lineCount,proto3" json:"line_count,omitempty"`
+	Deprecated      bool                    `protobuf:"varint,16,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	DeprecationNote string                  `protobuf:"bytes,17,opt,name=deprecation_note,json=deprecationNote,proto3" json:"deprecation_note,omitempty"`
+	HasDoc          bool                    `protobuf:"varint,18,opt,name=has_doc,json=hasDoc,proto3" json:"has_doc,omitempty"`
+	Closures        []*ClosureDescription   `protobuf:"bytes,19,rep,name=closures,proto3" json:"closures,omitempty"`
+	ReferencedTypes []string                `protobuf:"bytes,20,rep,name=referenced_types,json=referencedTypes,proto3" json:"referenced_types,omitempty"`
+	Exported        bool                    `protobuf:"varint,21,opt,name=exported,proto3" json:"exported,omitempty"`
+	Warnings        []string                `protobuf:"bytes,22,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Root            string                  `protobuf:"bytes,23,opt,name=root,proto3" json:"root,omitempty"`
+	ReturnsError    bool                    `protobuf:"varint,24,opt,name=returns_error,json=returnsError,proto3" json:"returns_error,omitempty"`
+	AcceptsContext  bool                    `protobuf:"varint,25,opt,name=accepts_context,json=acceptsContext,proto3" json:"accepts_context,omitempty"`
+	GoStatements    int64                   `protobuf:"varint,26,opt,name=go_statements,json=goStatements,proto3" json:"go_statements,omitempty"`
+	Defers          int64                   `protobuf:"varint,27,opt,name=defers,proto3" json:"defers,omitempty"`
+	CallsPanic      bool                    `protobuf:"varint,28,opt,name=calls_panic,json=callsPanic,proto3" json:"calls_panic,omitempty"`
+	Parameters      []*ParameterDescription `protobuf:"bytes,29,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Results         []*ParameterDescription `protobuf:"bytes,30,rep,name=results,proto3" json:"results,omitempty"`
+	ParamCount      int64                   `protobuf:"varint,31,opt,name=param_count,json=paramCount,proto3" json:"param_count,omitempty"`
+	ResultCount     int64                   `protobuf:"varint,32,opt,name=result_count,json=resultCount,proto3" json:"result_count,omitempty"`
+	TypeParams      []*TypeParam            `protobuf:"bytes,33,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	ReceiverType    string                  `protobuf:"bytes,35,opt,name=receiver_type,json=receiverType,proto3" json:"receiver_type,omitempty"`
+}
+
+func (x *FunctionDescription) Reset() {
+	*x = FunctionDescription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_functions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FunctionDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionDescription) ProtoMessage() {}
+
+func (x *FunctionDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_functions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionDescription.ProtoReflect.Descriptor instead.
+func (*FunctionDescription) Descriptor() ([]byte, []int) {
+	return file_functions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FunctionDescription) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetDoc() string {
+	if x != nil {
+		return x.Doc
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetIsTestFunction() bool {
+	if x != nil {
+		return x.IsTestFunction
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetIsMethod() bool {
+	if x != nil {
+		return x.IsMethod
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetStartLine() int64 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetEndLine() int64 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetStartCol() int64 {
+	if x != nil {
+		return x.StartCol
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetCalls() []string {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetComplexity() int64 {
+	if x != nil {
+		return x.Complexity
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetLineCount() int64 {
+	if x != nil {
+		return x.LineCount
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetDeprecationNote() string {
+	if x != nil {
+		return x.DeprecationNote
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetHasDoc() bool {
+	if x != nil {
+		return x.HasDoc
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetClosures() []*ClosureDescription {
+	if x != nil {
+		return x.Closures
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetReferencedTypes() []string {
+	if x != nil {
+		return x.ReferencedTypes
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetExported() bool {
+	if x != nil {
+		return x.Exported
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetReturnsError() bool {
+	if x != nil {
+		return x.ReturnsError
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetAcceptsContext() bool {
+	if x != nil {
+		return x.AcceptsContext
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetGoStatements() int64 {
+	if x != nil {
+		return x.GoStatements
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetDefers() int64 {
+	if x != nil {
+		return x.Defers
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetCallsPanic() bool {
+	if x != nil {
+		return x.CallsPanic
+	}
+	return false
+}
+
+func (x *FunctionDescription) GetParameters() []*ParameterDescription {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetResults() []*ParameterDescription {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetParamCount() int64 {
+	if x != nil {
+		return x.ParamCount
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetResultCount() int64 {
+	if x != nil {
+		return x.ResultCount
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetTypeParams() []*TypeParam {
+	if x != nil {
+		return x.TypeParams
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetReceiverType() string {
+	if x != nil {
+		return x.ReceiverType
+	}
+	return ""
+}
+
+type TypeParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Constraint string `protobuf:"bytes,2,opt,name=constraint,proto3" json:"constraint,omitempty"`
+}
+
+func (x *TypeParam) Reset() {
+	*x = TypeParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_functions_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypeParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeParam) ProtoMessage() {}
+
+func (x *TypeParam) ProtoReflect() protoreflect.Message {
+	mi := &file_functions_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeParam.ProtoReflect.Descriptor instead.
+func (*TypeParam) Descriptor() ([]byte, []int) {
+	return file_functions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TypeParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TypeParam) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+type ParameterDescription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	External bool   `protobuf:"varint,3,opt,name=external,proto3" json:"external,omitempty"`
+}
+
+func (x *ParameterDescription) Reset() {
+	*x = ParameterDescription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_functions_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParameterDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterDescription) ProtoMessage() {}
+
+func (x *ParameterDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_functions_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterDescription.ProtoReflect.Descriptor instead.
+func (*ParameterDescription) Descriptor() ([]byte, []int) {
+	return file_functions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ParameterDescription) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParameterDescription) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ParameterDescription) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+type ClosureDescription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signature string `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	StartLine int64  `protobuf:"varint,2,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	EndLine   int64  `protobuf:"varint,3,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	StartCol  int64  `protobuf:"varint,4,opt,name=start_col,json=startCol,proto3" json:"start_col,omitempty"`
+}
+
+func (x *ClosureDescription) Reset() {
+	*x = ClosureDescription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_functions_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClosureDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosureDescription) ProtoMessage() {}
+
+func (x *ClosureDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_functions_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosureDescription.ProtoReflect.Descriptor instead.
+func (*ClosureDescription) Descriptor() ([]byte, []int) {
+	return file_functions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ClosureDescription) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *ClosureDescription) GetStartLine() int64 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *ClosureDescription) GetEndLine() int64 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *ClosureDescription) GetStartCol() int64 {
+	if x != nil {
+		return x.StartCol
+	}
+	return 0
+}
+
+var File_functions_proto protoreflect.FileDescriptor
+
+var file_functions_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x07, 0x67, 0x6f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x22, 0xe1, 0x01, 0x0a, 0x14, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3a, 0x0a,
+	0x09, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x43, 0x0a, 0x0e, 0x74, 0x65, 0x73,
+	0x74, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x2e, 0x46, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0d, 0x74, 0x65, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x98,
+	0x09, 0x0a, 0x13, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6f,
+	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6f, 0x63, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x28, 0x0a, 0x10, 0x69, 0x73, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x73, 0x54,
+	0x65, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x69,
+	0x73, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x69, 0x73, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x4c, 0x69,
+	0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x78,
+	0x69, 0x74, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x78, 0x69, 0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x74, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x5f, 0x64, 0x6f, 0x63, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x68, 0x61, 0x73, 0x44, 0x6f, 0x63, 0x12, 0x37, 0x0a, 0x08, 0x63, 0x6c, 0x6f, 0x73,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x73, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x27, 0x0a,
+	0x0f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x67, 0x6f, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x67,
+	0x6f, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x66, 0x65, 0x72, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x64, 0x65, 0x66,
+	0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x5f, 0x70, 0x61, 0x6e,
+	0x69, 0x63, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x50,
+	0x61, 0x6e, 0x69, 0x63, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x1e,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x6f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x2e, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1f, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x33, 0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
+	0x21, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x0a, 0x74, 0x79, 0x70, 0x65, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x4a, 0x04, 0x08, 0x22, 0x10, 0x23,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x3f, 0x0a, 0x09, 0x54, 0x79, 0x70,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x22, 0x5a, 0x0a, 0x14, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x22, 0x89, 0x01, 0x0a, 0x12, 0x43, 0x6c, 0x6f, 0x73, 0x75,
+	0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e,
+	0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e,
+	0x64, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x63,
+	0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43,
+	0x6f, 0x6c, 0x42, 0x0a, 0x5a, 0x08, 0x70, 0x61, 0x72, 0x73, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_functions_proto_rawDescOnce sync.Once
+	file_functions_proto_rawDescData = file_functions_proto_rawDesc
+)
+
+func file_functions_proto_rawDescGZIP() []byte {
+	file_functions_proto_rawDescOnce.Do(func() {
+		file_functions_proto_rawDescData = protoimpl.X.CompressGZIP(file_functions_proto_rawDescData)
+	})
+	return file_functions_proto_rawDescData
+}
+
+var file_functions_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_functions_proto_goTypes = []any{
+	(*FunctionDescriptions)(nil), // 0: goparse.FunctionDescriptions
+	(*FunctionDescription)(nil),  // 1: goparse.FunctionDescription
+	(*TypeParam)(nil),            // 2: goparse.TypeParam
+	(*ParameterDescription)(nil), // 3: goparse.ParameterDescription
+	(*ClosureDescription)(nil),   // 4: goparse.ClosureDescription
+}
+var file_functions_proto_depIdxs = []int32{
+	1, // 0: goparse.FunctionDescriptions.functions:type_name -> goparse.FunctionDescription
+	1, // 1: goparse.FunctionDescriptions.test_functions:type_name -> goparse.FunctionDescription
+	4, // 2: goparse.FunctionDescription.closures:type_name -> goparse.ClosureDescription
+	3, // 3: goparse.FunctionDescription.parameters:type_name -> goparse.ParameterDescription
+	3, // 4: goparse.FunctionDescription.results:type_name -> goparse.ParameterDescription
+	2, // 5: goparse.FunctionDescription.type_params:type_name -> goparse.TypeParam
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_functions_proto_init() }
+func file_functions_proto_init() {
+	if File_functions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_functions_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*FunctionDescriptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_functions_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*FunctionDescription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_functions_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*TypeParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_functions_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ParameterDescription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_functions_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ClosureDescription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_functions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_functions_proto_goTypes,
+		DependencyIndexes: file_functions_proto_depIdxs,
+		MessageInfos:      file_functions_proto_msgTypes,
+	}.Build()
+	File_functions_proto = out.File
+	file_functions_proto_rawDesc = nil
+	file_functions_proto_goTypes = nil
+	file_functions_proto_depIdxs = nil
+}
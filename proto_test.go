@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"parse/pb"
+)
+
+// decodeFunctionDescriptions decodes b as the FunctionDescriptions message
+// generated from functions.proto.
+func decodeFunctionDescriptions(t *testing.T, b []byte) protoreflect.Message {
+	t.Helper()
+	var msg pb.FunctionDescriptions
+	if err := proto.Unmarshal(b, &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg.ProtoReflect()
+}
+
+// protoJSON converts the populated fields of msg to the generic form
+// encoding/json decodes into, keyed by the proto field names.
+func protoJSON(msg protoreflect.Message) map[string]interface{} {
+	fields := make(map[string]interface{})
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() {
+			var items []interface{}
+			for i := 0; i < v.List().Len(); i++ {
+				items = append(items, protoScalarJSON(fd, v.List().Get(i)))
+			}
+			fields[string(fd.Name())] = items
+		} else {
+			fields[string(fd.Name())] = protoScalarJSON(fd, v)
+		}
+		return true
+	})
+	return fields
+}
+
+func protoScalarJSON(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return protoJSON(v.Message())
+	case protoreflect.Int64Kind:
+		return float64(v.Int())
+	case protoreflect.Uint32Kind:
+		return float64(v.Uint())
+	}
+	return v.Interface()
+}
+
+// nonZeroJSON returns the JSON form of v without the zero values proto3
+// leaves out.
+func nonZeroJSON(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		t.Fatal(err)
+	}
+	return dropZeroJSON(generic)
+}
+
+func dropZeroJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			value = dropZeroJSON(value)
+			if value == nil || value == "" || value == false || value == float64(0) {
+				delete(v, key)
+			} else {
+				v[key] = value
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, item := range v {
+			v[i] = dropZeroJSON(item)
+		}
+	}
+	return v
+}
+
+func TestProtobufMatchesSchema(t *testing.T) {
+	desc := FunctionDescription{
+		Name:            "Handle",
+		Doc:             "##Function name: Handle\n",
+		Signature:       "func (s *Server) Handle(ctx context.Context, t T) (int, error)",
+		Package:         "server",
+		FilePath:        "server/server.go",
+		Root:            "/src",
+		IsTestFunction:  true,
+		Kind:            kindRegular,
+		Receiver:        "s *Server",
+		ReceiverType:    "Server",
+		IsMethod:        true,
+		StartLine:       10,
+		EndLine:         20,
+		StartCol:        1,
+		Calls:           []string{"fmt.Println", "s.listen"},
+		Complexity:      3,
+		LineCount:       11,
+		Deprecated:      true,
+		DeprecationNote: "Use Serve.",
+		HasDoc:          true,
+		Closures:        []ClosureDescription{{Signature: "func()", StartLine: 12, EndLine: 14, StartCol: 5}},
+		ReferencedTypes: []string{"Server"},
+		Exported:        true,
+		Warnings:        []string{"12:5: a warning"},
+		ReturnsError:    true,
+		AcceptsContext:  true,
+		GoStatements:    1,
+		Defers:          2,
+		CallsPanic:      true,
+		Parameters: []ParameterDescription{
+			{Name: "ctx", Type: "context.Context", External: true},
+			{Name: "t", Type: "T"},
+		},
+		Results:     []ParameterDescription{{Type: "int"}, {Type: "error"}},
+		ParamCount:  2,
+		ResultCount: 2,
+		TypeParams:  []TypeParam{{Name: "T", Constraint: "any"}},
+	}
+	function := protoFunctionDescription(desc).ProtoReflect()
+	functionFields := function.Descriptor().Fields()
+	for i := 0; i < functionFields.Len(); i++ {
+		if fd := functionFields.Get(i); !function.Has(fd) {
+			t.Errorf("field %s is not encoded", fd.Name())
+		}
+	}
+	if got, want := protoJSON(function), nonZeroJSON(t, desc); !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded function = %v\nwant %v", got, want)
+	}
+}
+
+func TestProtobufFormat(t *testing.T) {
+	files := map[string]string{
+		"server.go": `package server
+
+import "context"
+
+// Serve serves.
+func Serve(ctx context.Context, addrs ...string) error {
+	defer func() {}()
+	return nil
+}
+
+func (s *Server) listen() {}
+
+type Server struct{}
+`,
+		"server_test.go": "package server\n\nimport \"testing\"\n\nfunc TestServe(t *testing.T) {}\n",
+	}
+	root := writeProject(t, files)
+	p := newTestProcessor(t, root)
+	p.Format = formatProtobuf
+	if err := p.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(p.OutputPath, "functions.pb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := decodeFunctionDescriptions(t, b)
+
+	jsonOut := newTestProcessor(t, root)
+	if err := jsonOut.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	functions := readJSONOutput(t, jsonOut.OutputPath, defaultFunctionsFile).Functions
+	testFunctions := readJSONOutput(t, jsonOut.OutputPath, defaultTestsFile).TestFunctions
+
+	decoded := protoJSON(msg)
+	if got := decoded["schema_version"]; got != float64(schemaVersion) {
+		t.Errorf("schema_version = %v, want %d", got, schemaVersion)
+	}
+	if _, err := time.Parse(time.RFC3339, decoded["generated_at"].(string)); err != nil {
+		t.Errorf("generated_at: %v", err)
+	}
+	if got, want := decoded["functions"], nonZeroJSON(t, functions); !reflect.DeepEqual(got, want) {
+		t.Errorf("functions = %v\nwant %v", got, want)
+	}
+	if got, want := decoded["test_functions"], nonZeroJSON(t, testFunctions); !reflect.DeepEqual(got, want) {
+		t.Errorf("test functions = %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// filterChanged keeps only the files that git reports as changed since ref.
+// Without a git binary, or when a project is not in a git work tree, every
+// file is kept.
+func (p *ProjectProcessor) filterChanged(goFiles []projectFile) ([]projectFile, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		p.logger().Printf("git is not available, parsing all files instead of the changes since %s", p.Since)
+		return goFiles, nil
+	}
+
+	changed := make(map[string]bool)
+	for _, root := range p.ProjectPaths {
+		dir := root
+		if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+			dir = filepath.Dir(dir)
+		}
+
+		if !isGitWorkTree(dir) {
+			p.logger().Printf("%s is not in a git work tree, parsing all files instead of the changes since %s", root, p.Since)
+			return goFiles, nil
+		}
+		if err := changedFiles(dir, p.Since, changed); err != nil {
+			return nil, err
+		}
+	}
+
+	var filtered []projectFile
+	for _, goFile := range goFiles {
+		if changed[filepath.Clean(goFile.path)] {
+			filtered = append(filtered, goFile)
+		}
+	}
+	return filtered, nil
+}
+
+// changedFiles adds to changed the cleaned paths, joined to dir, of the files
+// under dir that differ between ref and the working tree.
+func changedFiles(dir, ref string, changed map[string]bool) error {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", "diff", "--name-only", "--relative", ref, "--")
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to list files changed since %s: %v: %s", ref, err, firstLine(stderr.String()))
+	}
+
+	for _, name := range strings.Split(stdout.String(), "\n") {
+		if name != "" {
+			changed[filepath.Join(dir, filepath.FromSlash(name))] = true
+		}
+	}
+	return nil
+}
+
+func isGitWorkTree(dir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// initGitRepo makes root a git repository with one commit of its files.
+func initGitRepo(t *testing.T, root string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+func TestSince(t *testing.T) {
+	files := map[string]string{
+		"a.go":     "package p\n\nfunc A() {}\n",
+		"b.go":     "package p\n\nfunc B() {}\n",
+		"sub/c.go": "package sub\n\nfunc C() {}\n",
+	}
+	tests := []struct {
+		name    string
+		git     bool
+		change  []string
+		path    string
+		since   string
+		want    []string
+		wantErr string
+	}{
+		{name: "changed files", git: true, change: []string{"b.go", "sub/c.go"}, since: "HEAD", want: []string{"B", "C"}},
+		{name: "subdirectory project", git: true, change: []string{"b.go", "sub/c.go"}, path: "sub", since: "HEAD", want: []string{"C"}},
+		{name: "nothing changed", git: true, since: "HEAD", wantErr: "changed since HEAD"},
+		{name: "unknown ref", git: true, since: "no-such-ref", wantErr: "failed to list files changed since no-such-ref"},
+		{name: "not a work tree", change: []string{"b.go"}, since: "HEAD", want: []string{"A", "B", "C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProject(t, files)
+			t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(root))
+			t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+			t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+			if tt.git {
+				initGitRepo(t, root)
+			}
+			for _, name := range tt.change {
+				path := filepath.Join(root, filepath.FromSlash(name))
+				f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.WriteString("\nfunc changed() {}\n")
+				f.Close()
+			}
+
+			p := newTestProcessor(t, filepath.Join(root, filepath.FromSlash(tt.path)))
+			p.Since = tt.since
+			p.ExcludeFuncs = "^changed$"
+			err := p.Process(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "\n") {
+					t.Errorf("error spans several lines: %q", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := functionNames(readJSONOutput(t, p.OutputPath, defaultFunctionsFile).Functions)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSinceWithoutGit(t *testing.T) {
+	root := writeProject(t, map[string]string{"a.go": "package p\n\nfunc A() {}\n"})
+	t.Setenv("PATH", t.TempDir())
+	p := newTestProcessor(t, root)
+	p.Since = "HEAD"
+	if err := p.Process(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := functionNames(readJSONOutput(t, p.OutputPath, defaultFunctionsFile).Functions); !reflect.DeepEqual(got, []string{"A"}) {
+		t.Errorf("functions = %q, want all of them", got)
+	}
+}
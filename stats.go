@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path"
+	"sort"
+)
+
+// PackageStats aggregates the functions of one package in one directory of
+// one project root. AverageComplexity covers functions and methods but not
+// test functions; TotalLines counts the lines of all of them.
+type PackageStats struct {
+	Name              string  `json:"name" yaml:"name"`
+	Root              string  `json:"root" yaml:"root"`
+	Dir               string  `json:"dir" yaml:"dir"`
+	Functions         int     `json:"functions" yaml:"functions"`
+	Methods           int     `json:"methods" yaml:"methods"`
+	TestFunctions     int     `json:"test_functions" yaml:"test_functions"`
+	AverageComplexity float64 `json:"average_complexity" yaml:"average_complexity"`
+	TotalLines        int     `json:"total_lines" yaml:"total_lines"`
+}
+
+func buildPackageStats(funcDescriptions Func) []PackageStats {
+	type key struct{ name, root, dir string }
+	byPackage := make(map[key]*PackageStats)
+	complexity := make(map[key]int)
+	get := func(desc FunctionDescription) (key, *PackageStats) {
+		k := key{desc.Package, desc.Root, path.Dir(desc.FilePath)}
+		stats, ok := byPackage[k]
+		if !ok {
+			stats = &PackageStats{Name: k.name, Root: k.root, Dir: k.dir}
+			byPackage[k] = stats
+		}
+		return k, stats
+	}
+
+	for _, desc := range funcDescriptions.FunctionDescriptions {
+		k, stats := get(desc)
+		if desc.IsMethod {
+			stats.Methods++
+		} else {
+			stats.Functions++
+		}
+		stats.TotalLines += desc.LineCount
+		complexity[k] += desc.Complexity
+	}
+	for _, desc := range funcDescriptions.TestFunctionDescriptions {
+		_, stats := get(desc)
+		stats.TestFunctions++
+		stats.TotalLines += desc.LineCount
+	}
+
+	packages := make([]PackageStats, 0, len(byPackage))
+	for k, stats := range byPackage {
+		if n := stats.Functions + stats.Methods; n > 0 {
+			stats.AverageComplexity = float64(complexity[k]) / float64(n)
+		}
+		packages = append(packages, *stats)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Root != packages[j].Root {
+			return packages[i].Root < packages[j].Root
+		}
+		if packages[i].Dir != packages[j].Dir {
+			return packages[i].Dir < packages[j].Dir
+		}
+		return packages[i].Name < packages[j].Name
+	})
+	return packages
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildPackageStats(t *testing.T) {
+	server := parseTestSource(t, "server/server.go", `package server
+
+func Serve(ok bool) {
+	if ok {
+		return
+	}
+}
+
+func (s *Server) Stop() {}
+`, Param{Root: "/api"})
+	server.Merge(parseTestSource(t, "server/server_test.go", "package server\n\nimport \"testing\"\n\nfunc TestServe(t *testing.T) {}\n", Param{Root: "/api"}))
+	store := parseTestSource(t, "store/store.go", "package store\n\nfunc Get() {}\n\nfunc Put() {}\n", Param{Root: "/api"})
+	worker := parseTestSource(t, "server/server.go", "package server\n\nfunc Work() {}\n", Param{Root: "/worker"})
+
+	all := server
+	all.Merge(store)
+	all.Merge(worker)
+
+	tests := []struct {
+		name  string
+		funcs Func
+		want  []PackageStats
+	}{
+		{"empty", Func{}, []PackageStats{}},
+		{
+			"two packages",
+			all,
+			[]PackageStats{
+				{Name: "server", Root: "/api", Dir: "server", Functions: 1, Methods: 1, TestFunctions: 1, AverageComplexity: 1.5, TotalLines: 7},
+				{Name: "store", Root: "/api", Dir: "store", Functions: 2, AverageComplexity: 1, TotalLines: 2},
+				{Name: "server", Root: "/worker", Dir: "server", Functions: 1, AverageComplexity: 1, TotalLines: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildPackageStats(tt.funcs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stats = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+type TodoDescription struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Text     string `json:"text" yaml:"text"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Line     int    `json:"line" yaml:"line"`
+}
+
+var todoMarkers = []string{"TODO", "FIXME"}
+
+// describeTodos records every comment, doc comment or not, that mentions
+// one of todoMarkers. The kind is the first marker found in the comment.
+func describeTodos(p Param, file *ast.File, src source) []TodoDescription {
+	var todos []TodoDescription
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			kind := todoKind(c.Text)
+			if kind == "" {
+				continue
+			}
+			todos = append(todos, TodoDescription{
+				Kind:     kind,
+				Text:     commentText(c.Text),
+				FilePath: p.FilePath,
+				Line:     src.file.Position(c.Pos()).Line,
+			})
+		}
+	}
+	return todos
+}
+
+func todoKind(text string) string {
+	kind, index := "", -1
+	for _, marker := range todoMarkers {
+		if i := strings.Index(text, marker); i >= 0 && (index < 0 || i < index) {
+			kind, index = marker, i
+		}
+	}
+	return kind
+}
+
+// commentText strips the comment markers from a single // or /* */ comment.
+func commentText(text string) string {
+	if strings.HasPrefix(text, "//") {
+		return strings.TrimSpace(text[2:])
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/"))
+}
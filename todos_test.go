@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribeTodos(t *testing.T) {
+	src := `package work
+
+// Run runs.
+// TODO: handle errors.
+func Run() {
+	x := 1 // FIXME overflow
+	/* TODO(alice): make this faster */
+	_ = x
+}
+
+// Nothing to do here.
+func Done() {}
+`
+	todos := parseTestSource(t, "work/run.go", src, Param{}).TodoDescriptions
+	want := []TodoDescription{
+		{Kind: "TODO", Text: "TODO: handle errors.", FilePath: "work/run.go", Line: 4},
+		{Kind: "FIXME", Text: "FIXME overflow", FilePath: "work/run.go", Line: 6},
+		{Kind: "TODO", Text: "TODO(alice): make this faster", FilePath: "work/run.go", Line: 7},
+	}
+	if !reflect.DeepEqual(todos, want) {
+		t.Errorf("todos = %+v\nwant %+v", todos, want)
+	}
+}
+
+func TestTodoKind(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"// TODO: x", "TODO"},
+		{"// FIXME: x", "FIXME"},
+		{"// FIXME: see TODO below", "FIXME"},
+		{"// nothing", ""},
+		{"// todo in lower case", ""},
+	}
+	for _, tt := range tests {
+		if got := todoKind(tt.text); got != tt.want {
+			t.Errorf("todoKind(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
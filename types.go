@@ -0,0 +1,167 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+const (
+	typeKindStruct    = "struct"
+	typeKindInterface = "interface"
+)
+
+type TypeDescription struct {
+	Name     string              `json:"name" yaml:"name"`
+	Kind     string              `json:"kind" yaml:"kind"`
+	Doc      string              `json:"doc" yaml:"doc"`
+	Package  string              `json:"package" yaml:"package"`
+	FilePath string              `json:"file_path" yaml:"file_path"`
+	Line     int                 `json:"line" yaml:"line"`
+	Fields   []FieldDescription  `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Methods  []MethodDescription `json:"methods,omitempty" yaml:"methods,omitempty"`
+	Embedded []string            `json:"embedded,omitempty" yaml:"embedded,omitempty"`
+}
+
+type FieldDescription struct {
+	Name     string            `json:"name" yaml:"name"`
+	Type     string            `json:"type" yaml:"type"`
+	Embedded bool              `json:"embedded" yaml:"embedded"`
+	Tag      string            `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+type MethodDescription struct {
+	Name      string `json:"name" yaml:"name"`
+	Signature string `json:"signature" yaml:"signature"`
+}
+
+func describeTypes(p Param, file *ast.File, src source) []TypeDescription {
+	var types []TypeDescription
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if p.ExportedOnly && !ts.Name.IsExported() {
+				continue
+			}
+
+			desc := TypeDescription{
+				Name:     ts.Name.Name,
+				Doc:      specDoc(gen, ts.Doc),
+				Package:  file.Name.Name,
+				FilePath: p.FilePath,
+				Line:     src.file.Position(ts.Pos()).Line,
+			}
+			switch t := ts.Type.(type) {
+			case *ast.StructType:
+				desc.Kind = typeKindStruct
+				desc.Fields = structFields(t)
+			case *ast.InterfaceType:
+				desc.Kind = typeKindInterface
+				desc.Methods, desc.Embedded = interfaceMethods(t)
+			default:
+				continue
+			}
+			types = append(types, desc)
+		}
+	}
+	return types
+}
+
+// specDoc returns the doc comment of a spec in gen. A lone "type X ..." or
+// "var x ..." declaration attaches its comment to the GenDecl instead.
+func specDoc(gen *ast.GenDecl, doc *ast.CommentGroup) string {
+	if doc != nil {
+		return doc.Text()
+	}
+	if gen.Doc != nil && len(gen.Specs) == 1 {
+		return gen.Doc.Text()
+	}
+	return ""
+}
+
+func structFields(st *ast.StructType) []FieldDescription {
+	var descriptions []FieldDescription
+	for _, f := range st.Fields.List {
+		field := FieldDescription{Type: expr(f.Type)}
+		if f.Tag != nil {
+			if tag, err := strconv.Unquote(f.Tag.Value); err == nil {
+				field.Tag = tag
+				field.Tags = parseStructTag(tag)
+			}
+		}
+
+		if len(f.Names) == 0 {
+			field.Name = baseTypeName(f.Type)
+			field.Embedded = true
+			descriptions = append(descriptions, field)
+			continue
+		}
+		for _, n := range f.Names {
+			field.Name = n.Name
+			descriptions = append(descriptions, field)
+		}
+	}
+	return descriptions
+}
+
+func interfaceMethods(it *ast.InterfaceType) ([]MethodDescription, []string) {
+	var methods []MethodDescription
+	var embedded []string
+	for _, m := range it.Methods.List {
+		ft, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			embedded = append(embedded, expr(m.Type))
+			continue
+		}
+		for _, n := range m.Names {
+			methods = append(methods, MethodDescription{Name: n.Name, Signature: n.Name + signature(ft)})
+		}
+	}
+	return methods, embedded
+}
+
+// parseStructTag splits a tag in the conventional key:"value" format into
+// its pairs. Parsing stops at the first malformed pair, as reflect does.
+func parseStructTag(tag string) map[string]string {
+	tags := make(map[string]string)
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+
+		colon := strings.Index(tag, ":")
+		if colon <= 0 || colon+1 >= len(tag) || tag[colon+1] != '"' || strings.ContainsAny(tag[:colon], " \"") {
+			break
+		}
+		key := tag[:colon]
+		tag = tag[colon+1:]
+
+		end := 1
+		for end < len(tag) && tag[end] != '"' {
+			if tag[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:end+1])
+		if err != nil {
+			break
+		}
+		tags[key] = value
+		tag = tag[end+1:]
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribeStructs(t *testing.T) {
+	src := `package shapes
+
+// Point is a point in space.
+type Point struct {
+	X, Y int
+	Label string
+}
+
+type (
+	// Empty has no fields.
+	Empty struct{}
+	ID int
+)
+`
+	types := parseTestSource(t, "shapes.go", src, Param{}).TypeDescriptions
+	want := []TypeDescription{
+		{
+			Name:     "Point",
+			Kind:     typeKindStruct,
+			Doc:      "Point is a point in space.\n",
+			Package:  "shapes",
+			FilePath: "shapes.go",
+			Line:     4,
+			Fields: []FieldDescription{
+				{Name: "X", Type: "int"},
+				{Name: "Y", Type: "int"},
+				{Name: "Label", Type: "string"},
+			},
+		},
+		{
+			Name:     "Empty",
+			Kind:     typeKindStruct,
+			Doc:      "Empty has no fields.\n",
+			Package:  "shapes",
+			FilePath: "shapes.go",
+			Line:     11,
+		},
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("types = %+v\nwant %+v", types, want)
+	}
+}
+
+func TestDescribeInterfaces(t *testing.T) {
+	src := `package store
+
+// Store keeps values.
+type Store interface {
+	io.Closer
+	Get(key string) (string, error)
+	Put(key, value string) error
+}
+`
+	types := parseTestSource(t, "store.go", src, Param{}).TypeDescriptions
+	want := []TypeDescription{{
+		Name:     "Store",
+		Kind:     typeKindInterface,
+		Doc:      "Store keeps values.\n",
+		Package:  "store",
+		FilePath: "store.go",
+		Line:     4,
+		Methods: []MethodDescription{
+			{Name: "Get", Signature: "Get(key string) (string, error)"},
+			{Name: "Put", Signature: "Put(key, value string) error"},
+		},
+		Embedded: []string{"io.Closer"},
+	}}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("types = %+v\nwant %+v", types, want)
+	}
+}
+
+func TestStructTags(t *testing.T) {
+	src := "package model\n\ntype User struct {\n" +
+		"\tID   int    `json:\"id\" db:\"user_id\"`\n" +
+		"\tName string `json:\"name,omitempty\"`\n" +
+		"\tAge  int\n" +
+		"}\n"
+	fields := parseTestSource(t, "user.go", src, Param{}).TypeDescriptions[0].Fields
+	want := []FieldDescription{
+		{Name: "ID", Type: "int", Tag: `json:"id" db:"user_id"`, Tags: map[string]string{"json": "id", "db": "user_id"}},
+		{Name: "Name", Type: "string", Tag: `json:"name,omitempty"`, Tags: map[string]string{"json": "name,omitempty"}},
+		{Name: "Age", Type: "int"},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %+v\nwant %+v", fields, want)
+	}
+}
+
+func TestParseStructTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want map[string]string
+	}{
+		{``, nil},
+		{`json:"a"`, map[string]string{"json": "a"}},
+		{`json:"a"  db:"b"`, map[string]string{"json": "a", "db": "b"}},
+		{`json:"a \"quoted\""`, map[string]string{"json": `a "quoted"`}},
+		{`json:"a" malformed db:"b"`, map[string]string{"json": "a"}},
+		{`json:a`, nil},
+	}
+	for _, tt := range tests {
+		if got := parseStructTag(tt.tag); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseStructTag(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// walk calls fn for every file and directory under root, like filepath.Walk.
+// With FollowSymlinks, symlinked directories are walked as if they were
+// regular directories, reported under their path inside root. Every
+// directory is walked at most once, which also breaks symlink cycles.
+func (p *ProjectProcessor) walk(root string, fn filepath.WalkFunc) error {
+	if !p.FollowSymlinks {
+		return filepath.Walk(root, fn)
+	}
+	return walkFollowingSymlinks(root, fn, make(map[string]bool))
+}
+
+func walkFollowingSymlinks(root string, fn filepath.WalkFunc, visited map[string]bool) error {
+	real, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+	if visited[real] {
+		return nil
+	}
+	visited[real] = true
+
+	return filepath.WalkDir(real, func(path string, d fs.DirEntry, err error) error {
+		rel, relErr := filepath.Rel(real, path)
+		if relErr != nil {
+			return relErr
+		}
+		logical := filepath.Join(root, rel)
+		if err != nil {
+			return fn(logical, nil, err)
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(path)
+			if err != nil {
+				return fn(logical, nil, err)
+			}
+			if info.IsDir() {
+				return walkFollowingSymlinks(logical, fn, visited)
+			}
+			return fn(logical, info, nil)
+		}
+
+		if d.IsDir() && path != real {
+			// A directory reached here may also be the target of a symlink
+			// walked before or after it, so every one is recorded.
+			dir, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				return fn(logical, nil, err)
+			}
+			if visited[dir] {
+				return filepath.SkipDir
+			}
+			visited[dir] = true
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fn(logical, nil, err)
+		}
+		if path == real {
+			info = namedFileInfo{FileInfo: info, name: filepath.Base(logical)}
+		}
+		return fn(logical, info, nil)
+	})
+}
+
+// namedFileInfo reports a symlinked directory under the name of the link
+// rather than the name of its target.
+type namedFileInfo struct {
+	os.FileInfo
+	name string
+}
+
+func (i namedFileInfo) Name() string {
+	return i.name
+}
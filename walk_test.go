@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFollowSymlinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		links  map[string]string
+		follow bool
+		want   []string
+	}{
+		{
+			name:   "not followed",
+			files:  map[string]string{"p/main.go": "package main\n", "lib/lib.go": "package lib\n"},
+			links:  map[string]string{"p/lib": "../lib"},
+			follow: false,
+			want:   []string{"main.go"},
+		},
+		{
+			name:   "directory outside the root",
+			files:  map[string]string{"p/main.go": "package main\n", "lib/lib.go": "package lib\n"},
+			links:  map[string]string{"p/lib": "../lib"},
+			follow: true,
+			want:   []string{"lib/lib.go", "main.go"},
+		},
+		{
+			name:   "link to the root",
+			files:  map[string]string{"p/main.go": "package main\n"},
+			links:  map[string]string{"p/loop": "."},
+			follow: true,
+			want:   []string{"main.go"},
+		},
+		{
+			name:   "link to a directory inside the root",
+			files:  map[string]string{"p/a/a.go": "package a\n"},
+			links:  map[string]string{"p/z": "a"},
+			follow: true,
+			want:   []string{"a/a.go"},
+		},
+		{
+			name:   "cycle through a sibling",
+			files:  map[string]string{"p/a/a.go": "package a\n", "p/b/.keep": ""},
+			links:  map[string]string{"p/b/link": "../a", "p/a/back": "../b"},
+			follow: true,
+			want:   []string{"a/a.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProject(t, tt.files)
+			for name, target := range tt.links {
+				if err := os.Symlink(target, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+					t.Skipf("cannot create symlinks: %v", err)
+				}
+			}
+			root := filepath.Join(dir, "p")
+			p := newTestProcessor(t, root)
+			p.FollowSymlinks = tt.follow
+			if got := relGoFiles(t, p, root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}